@@ -0,0 +1,30 @@
+package account
+
+///////
+// ABA check is registered as a validation tag rather than checked after validation,
+// so failures are reported for BankID field the same way as its length is.
+///////
+const abaTag = "aba"
+
+// isABARoutingNumber checks US ABA routing number: 9 digits, Federal Reserve routing symbol prefix
+// and 3-7-1 weighted checksum.
+func isABARoutingNumber(routingNumber string) bool {
+	if len(routingNumber) != 9 || !isDigits(routingNumber) {
+		return false
+	}
+	if !isFederalReservePrefix((routingNumber[0]-'0')*10 + routingNumber[1] - '0') {
+		return false
+	}
+	weights := []int{3, 7, 1}
+	sum := 0
+	for i, r := range routingNumber {
+		sum += int(r-'0') * weights[i%3]
+	}
+	return sum%10 == 0
+}
+
+// prefixes are assigned to government (00), Federal Reserve districts (01-12), thrift institutions (21-32),
+// electronic transactions (61-72) and traveler's cheques (80).
+func isFederalReservePrefix(prefix byte) bool {
+	return prefix <= 12 || (prefix >= 21 && prefix <= 32) || (prefix >= 61 && prefix <= 72) || prefix == 80
+}
@@ -1,32 +1,55 @@
 package account
 
-import "time"
+import (
+	"time"
+
+	"github.com/r0kas/form3-accountapi-client/internal/stamp"
+)
+
+func init() {
+	stamp.Account = func(acc interface{}, version int, createdOn, modifiedOn time.Time) interface{} {
+		return acc.(*Account).withServerAttributes(version, createdOn, modifiedOn)
+	}
+}
 
 // Account represents organisation account.
 // Account object provides getter methods.
 // For creating new account use account Builder.
 type Account struct {
-	id                      string
-	versionIndex            int
-	createdOn               time.Time
-	modifiedOn              time.Time
-	organizationID          string
-	country                 string
-	baseCurrency            string
-	bankID                  string
-	bankIDCode              string
-	accountNumber           string
-	bic                     string
-	iban                    string
-	customerID              string
-	title                   string
-	firstName               string
-	bankAccountName         string
-	altBankAccountNames     []string
-	accountClassification   string
-	jointAccount            bool
-	accountMatchingOptOut   bool
-	secondaryIdentification string
+	id                         string
+	versionIndex               int
+	createdOn                  time.Time
+	modifiedOn                 time.Time
+	organizationID             string
+	country                    string
+	baseCurrency               string
+	bankID                     string
+	bankIDCode                 string
+	accountNumber              string
+	bic                        string
+	iban                       string
+	customerID                 string
+	title                      string
+	firstName                  string
+	bankAccountName            string
+	altBankAccountNames        []string
+	accountClassification      string
+	jointAccount               bool
+	accountMatchingOptOut      bool
+	secondaryIdentification    string
+	name                       []string
+	alternativeNames           []string
+	status                     string
+	statusReason               string
+	switched                   bool
+	userDefinedData            []UserDefinedData
+	validationType             string
+	referenceMask              string
+	acceptanceQualifier        string
+	processingService          string
+	privateIdentification      *PrivateIdentification
+	organisationIdentification *OrganisationIdentification
+	extra                      Extra
 }
 
 // ID unique identifier (UUID) of an account.
@@ -136,51 +159,170 @@ func (acc *Account) SecondaryIdentification() string {
 	return acc.secondaryIdentification
 }
 
+// Name - up to 4 lines of account holder's name, used for Confirmation of Payee matching.
+func (acc *Account) Name() []string {
+	return acc.name
+}
+
+// AlternativeNames - up to 3 alternative names of account holder, used for Confirmation of Payee matching.
+func (acc *Account) AlternativeNames() []string {
+	return acc.alternativeNames
+}
+
+// Status of the account, e.g. 'confirmed'.
+func (acc *Account) Status() string {
+	return acc.status
+}
+
+// StatusReason explains the status of the account, e.g. why it is closed.
+func (acc *Account) StatusReason() string {
+	return acc.statusReason
+}
+
+// IsSwitched - True if the account has been switched away from the organisation, e.g. with Current Account Switch Service.
+func (acc *Account) IsSwitched() bool {
+	return acc.switched
+}
+
+// UserDefinedData - free-format key value pairs stored with the account.
+func (acc *Account) UserDefinedData() []UserDefinedData {
+	return acc.userDefinedData
+}
+
+// ValidationType - type of the account validation, e.g. 'card' for accounts backing payment cards.
+func (acc *Account) ValidationType() string {
+	return acc.validationType
+}
+
+// ReferenceMask - mask of payment references accepted by the account, e.g. '############'.
+func (acc *Account) ReferenceMask() string {
+	return acc.referenceMask
+}
+
+// AcceptanceQualifier - when payments to the account are accepted, e.g. 'same_day'.
+func (acc *Account) AcceptanceQualifier() string {
+	return acc.acceptanceQualifier
+}
+
+// ProcessingService - name of the service processing payments of the account.
+func (acc *Account) ProcessingService() string {
+	return acc.processingService
+}
+
+// PrivateIdentification of account holder who is a person. Nil if not set.
+func (acc *Account) PrivateIdentification() *PrivateIdentification {
+	return acc.privateIdentification
+}
+
+// OrganisationIdentification of account holder which is an organisation. Nil if not set.
+func (acc *Account) OrganisationIdentification() *OrganisationIdentification {
+	return acc.organisationIdentification
+}
+
+// Extra returns account JSON which the SDK does not model yet: unknown attributes, relationships and meta.
+// It is empty for accounts which were not received from accounts API.
+func (acc *Account) Extra() Extra {
+	return acc.extra.clone()
+}
+
+// returns a copy of account with attributes which are managed by API server: version number and creation and modification times.
+// Exposed to AccountsAPI implementations of this module through internal/stamp.
+func (acc *Account) withServerAttributes(version int, createdOn, modifiedOn time.Time) *Account {
+	stamped := *acc
+	stamped.versionIndex = version
+	stamped.createdOn = createdOn
+	stamped.modifiedOn = modifiedOn
+	if acc.altBankAccountNames != nil {
+		stamped.altBankAccountNames = append([]string(nil), acc.altBankAccountNames...)
+	}
+	if acc.name != nil {
+		stamped.name = append([]string(nil), acc.name...)
+	}
+	if acc.alternativeNames != nil {
+		stamped.alternativeNames = append([]string(nil), acc.alternativeNames...)
+	}
+	if acc.userDefinedData != nil {
+		stamped.userDefinedData = append([]UserDefinedData(nil), acc.userDefinedData...)
+	}
+	stamped.extra = acc.extra.clone()
+	return &stamped
+}
+
 // used for generating rest transport structures
 func (acc *Account) attributes() *accountAttributes {
 	return &accountAttributes{
-		Country:                 acc.Country(),
-		BaseCurrency:            acc.BaseCurrency(),
-		BankID:                  acc.BankID(),
-		BankIDCode:              acc.BankIDCode(),
-		AccountNumber:           acc.AccountNumber(),
-		Bic:                     acc.Bic(),
-		Iban:                    acc.Iban(),
-		CustomerID:              acc.CustomerID(),
-		Title:                   acc.Title(),
-		FirstName:               acc.FirstName(),
-		BankAccountName:         acc.BankAccountName(),
-		AltBankAccountNames:     acc.AltBankAccountNames(),
-		AccountClassification:   acc.AccountClassification(),
-		JointAccount:            acc.IsJointAccount(),
-		AccountMatchingOptOut:   acc.IsAccountMatchingOptOut(),
-		SecondaryIdentification: acc.SecondaryIdentification(),
+		Country:                    acc.Country(),
+		BaseCurrency:               acc.BaseCurrency(),
+		BankID:                     acc.BankID(),
+		BankIDCode:                 acc.BankIDCode(),
+		AccountNumber:              acc.AccountNumber(),
+		Bic:                        acc.Bic(),
+		Iban:                       acc.Iban(),
+		CustomerID:                 acc.CustomerID(),
+		Title:                      acc.Title(),
+		FirstName:                  acc.FirstName(),
+		BankAccountName:            acc.BankAccountName(),
+		AltBankAccountNames:        acc.AltBankAccountNames(),
+		AccountClassification:      acc.AccountClassification(),
+		JointAccount:               acc.IsJointAccount(),
+		AccountMatchingOptOut:      acc.IsAccountMatchingOptOut(),
+		SecondaryIdentification:    acc.SecondaryIdentification(),
+		Name:                       acc.Name(),
+		AlternativeNames:           acc.AlternativeNames(),
+		Status:                     acc.Status(),
+		StatusReason:               acc.StatusReason(),
+		Switched:                   acc.IsSwitched(),
+		UserDefinedData:            acc.UserDefinedData(),
+		ValidationType:             acc.ValidationType(),
+		ReferenceMask:              acc.ReferenceMask(),
+		AcceptanceQualifier:        acc.AcceptanceQualifier(),
+		ProcessingService:          acc.ProcessingService(),
+		PrivateIdentification:      acc.PrivateIdentification(),
+		OrganisationIdentification: acc.OrganisationIdentification(),
+		extra:                      acc.extra.Attributes,
 	}
 }
 
 // used for creating account object from received json transport structure
 func accountFrom(response transportData) *Account {
 	return &Account{
-		id:                      response.ID,
-		versionIndex:            response.Version,
-		createdOn:               response.CreatedOn,
-		modifiedOn:              response.ModifiedOn,
-		organizationID:          response.OrganizationID,
-		country:                 response.Attributes.Country,
-		baseCurrency:            response.Attributes.BaseCurrency,
-		bankID:                  response.Attributes.BankID,
-		bankIDCode:              response.Attributes.BankIDCode,
-		accountNumber:           response.Attributes.AccountNumber,
-		bic:                     response.Attributes.Bic,
-		iban:                    response.Attributes.Iban,
-		customerID:              response.Attributes.CustomerID,
-		title:                   response.Attributes.Title,
-		firstName:               response.Attributes.FirstName,
-		bankAccountName:         response.Attributes.BankAccountName,
-		altBankAccountNames:     response.Attributes.AltBankAccountNames,
-		accountClassification:   response.Attributes.AccountClassification,
-		jointAccount:            response.Attributes.JointAccount,
-		accountMatchingOptOut:   response.Attributes.AccountMatchingOptOut,
-		secondaryIdentification: response.Attributes.SecondaryIdentification,
+		id:                         response.ID,
+		versionIndex:               response.Version,
+		createdOn:                  response.CreatedOn,
+		modifiedOn:                 response.ModifiedOn,
+		organizationID:             response.OrganizationID,
+		country:                    response.Attributes.Country,
+		baseCurrency:               response.Attributes.BaseCurrency,
+		bankID:                     response.Attributes.BankID,
+		bankIDCode:                 response.Attributes.BankIDCode,
+		accountNumber:              response.Attributes.AccountNumber,
+		bic:                        response.Attributes.Bic,
+		iban:                       response.Attributes.Iban,
+		customerID:                 response.Attributes.CustomerID,
+		title:                      response.Attributes.Title,
+		firstName:                  response.Attributes.FirstName,
+		bankAccountName:            response.Attributes.BankAccountName,
+		altBankAccountNames:        response.Attributes.AltBankAccountNames,
+		accountClassification:      response.Attributes.AccountClassification,
+		jointAccount:               response.Attributes.JointAccount,
+		accountMatchingOptOut:      response.Attributes.AccountMatchingOptOut,
+		secondaryIdentification:    response.Attributes.SecondaryIdentification,
+		name:                       response.Attributes.Name,
+		alternativeNames:           response.Attributes.AlternativeNames,
+		status:                     response.Attributes.Status,
+		statusReason:               response.Attributes.StatusReason,
+		switched:                   response.Attributes.Switched,
+		userDefinedData:            response.Attributes.UserDefinedData,
+		validationType:             response.Attributes.ValidationType,
+		referenceMask:              response.Attributes.ReferenceMask,
+		acceptanceQualifier:        response.Attributes.AcceptanceQualifier,
+		processingService:          response.Attributes.ProcessingService,
+		privateIdentification:      response.Attributes.PrivateIdentification,
+		organisationIdentification: response.Attributes.OrganisationIdentification,
+		extra: Extra{
+			Attributes:    response.Attributes.extra,
+			Relationships: response.Relationships,
+			Meta:          response.Meta,
+		},
 	}
 }
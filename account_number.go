@@ -0,0 +1,90 @@
+package account
+
+import (
+	"github.com/pkg/errors"
+)
+
+// checkDigitsRule is reported in ValidationError when national check digits of account number are wrong.
+const checkDigitsRule = "check_digits"
+
+///////
+// Form3 account numbers do not include national check digits, but account holders often copy them
+// together with the account number from their statements. Both forms are accepted,
+// check digits are verified against bank ID when they are given.
+///////
+
+///////
+// Rules of supported countries can be replaced with RegisterCountry, keeping these checks together with
+// a different bank ID format. Hence every check verifies the bank ID it slices instead of trusting CountryRules.
+///////
+
+// checks Belgian account number of 7 digits followed by 2 mod-97 check digits of bank ID and account number.
+func belgianCheckDigitsCheck(bankID, accountNumber string) error {
+	if len(accountNumber) != 9 {
+		return nil
+	}
+	if err := requireCheckDigitsInput(bankID, 3, accountNumber); err != nil {
+		return err
+	}
+	if expected := belgianCheckDigits(bankID + accountNumber[:7]); accountNumber[7:] != expected {
+		return errors.Errorf("account number %s check digits must be %s", accountNumber, expected)
+	}
+	return nil
+}
+
+// checks French account number of 11 characters followed by 2 digit RIB key.
+func ribKeyCheck(bankID, accountNumber string) error {
+	if len(accountNumber) != 13 {
+		return nil
+	}
+	if !frenchAccountNumberWithKey.MatchString(accountNumber) {
+		return errors.Errorf("account number %s must end with 2 digit RIB key", accountNumber)
+	}
+	if err := requireCheckDigitsInput(bankID, 10, ""); err != nil {
+		return err
+	}
+	if expected := ribKey(bankID[:5], bankID[5:], accountNumber[:11]); accountNumber[11:] != expected {
+		return errors.Errorf("account number %s RIB key must be %s", accountNumber, expected)
+	}
+	return nil
+}
+
+// checks Spanish account number of 2 CCC control digits followed by 10 digits.
+func cccCheck(bankID, accountNumber string) error {
+	if len(accountNumber) != 12 {
+		return nil
+	}
+	if err := requireCheckDigitsInput(bankID, 8, accountNumber); err != nil {
+		return err
+	}
+	if expected := cccCheckDigits(bankID, accountNumber[2:]); accountNumber[:2] != expected {
+		return errors.Errorf("account number %s control digits must be %s", accountNumber, expected)
+	}
+	return nil
+}
+
+// checks Portuguese account number of 11 digits followed by 2 NIB check digits.
+func nibCheck(bankID, accountNumber string) error {
+	if len(accountNumber) != 13 {
+		return nil
+	}
+	if err := requireCheckDigitsInput(bankID, 8, accountNumber); err != nil {
+		return err
+	}
+	if expected := nibCheckDigits(bankID + accountNumber[:11]); accountNumber[11:] != expected {
+		return errors.Errorf("account number %s check digits must be %s", accountNumber, expected)
+	}
+	return nil
+}
+
+// check digits can only be calculated from bank ID of the given number of digits and account number of digits only.
+// Empty account number is not checked.
+func requireCheckDigitsInput(bankID string, bankIDLength int, accountNumber string) error {
+	if len(bankID) != bankIDLength || !isDigits(bankID) {
+		return errors.Errorf("bank ID %s must be %d digits to verify account number check digits", bankID, bankIDLength)
+	}
+	if accountNumber != "" && !isDigits(accountNumber) {
+		return errors.Errorf("account number %s must be digits to verify its check digits", accountNumber)
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+// Package accountfake provides an in-memory implementation of account.AccountsAPI.
+// It mirrors semantics of Form3 accounts API, so that services can be unit tested without a running API.
+package accountfake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	account "github.com/r0kas/form3-accountapi-client"
+	"github.com/r0kas/form3-accountapi-client/internal/stamp"
+)
+
+const (
+	endpoint        = "/v1/organisation/accounts"
+	defaultPageSize = 100
+)
+
+// Client stores accounts in memory and handles commands the same way accounts API does:
+//   - Create fails with 409 Conflict if account with the same ID exists
+//   - Fetch and Delete fail with 404 Not Found for unknown IDs
+//   - Delete fails with 409 Conflict if provided version is not the current one
+//   - conflicts carry error_code account.ErrorCodeDuplicateID or account.ErrorCodeVersionMismatch
+//   - List supports page[number] ('first', 'last' or number from 0) and page[size]
+//   - created_on and modified_on are stamped on create, modified_on and version are updated on patch
+//
+// API failures are returned as *account.APIError, so they can be matched with the same sentinel errors.
+type Client struct {
+	mu       sync.Mutex
+	accounts map[string]*account.Account
+	order    []string
+	healthy  bool
+	now      func() time.Time
+}
+
+var _ account.AccountsAPI = (*Client)(nil)
+
+// New creates empty and healthy in-memory accounts API.
+func New() *Client {
+	return &Client{
+		accounts: make(map[string]*account.Account),
+		healthy:  true,
+		now:      time.Now,
+	}
+}
+
+// SetHealthy changes result of IsHealthy, so that health check handling can be tested.
+func (c *Client) SetHealthy(healthy bool) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.healthy = healthy
+	return c
+}
+
+// SetClock replaces time source used for created_on and modified_on stamping.
+func (c *Client) SetClock(now func() time.Time) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+	return c
+}
+
+// Create stores provided account with version 0 and creation time stamped.
+func (c *Client) Create(ctx context.Context, acc *account.Account) (*account.Account, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		return nil, errors.New("cannot create account without initialized account object. Use account builder")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.accounts[acc.ID()]; exists {
+		return nil, conflictError(http.MethodPost, endpoint, account.ErrorCodeDuplicateID,
+			"Account cannot be created as it violates a duplicate constraint")
+	}
+	now := c.now().UTC()
+	stored := stamped(acc, 0, now, now)
+	c.accounts[acc.ID()] = stored
+	c.order = append(c.order, acc.ID())
+	return clone(stored), nil
+}
+
+// Fetch returns stored account by its ID.
+func (c *Client) Fetch(ctx context.Context, accountID string) (*account.Account, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(accountID); err != nil {
+		return nil, errors.Wrap(err, "provided account ID must be a valid UUID")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stored, exists := c.accounts[accountID]
+	if !exists {
+		return nil, apiError(http.MethodGet, endpoint+"/"+accountID, http.StatusNotFound,
+			fmt.Sprintf("record %s does not exist", accountID))
+	}
+	return clone(stored), nil
+}
+
+// List returns stored accounts in order of creation, matching filter and paging settings.
+// Filter is normalised and validated the same way HTTPClient does it.
+func (c *Client) List(ctx context.Context, paging *account.PaginationSettings, filter ...account.Filter) ([]account.Account, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if len(filter) > 1 {
+		return nil, errors.New("at most one filter can be provided")
+	}
+	var normalised *account.Filter
+	if len(filter) == 1 {
+		valid := filter[0].Normalise()
+		if err := valid.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid filter")
+		}
+		normalised = &valid
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	matching := make([]account.Account, 0)
+	for _, id := range c.order {
+		stored := c.accounts[id]
+		if normalised == nil || matches(stored, *normalised) {
+			matching = append(matching, *clone(stored))
+		}
+	}
+	if paging == nil || !paging.Enabled {
+		return matching, nil
+	}
+	return page(matching, paging)
+}
+
+// Patch replaces stored account if its version is the current one. Version is incremented and modification time stamped.
+// Not a part of AccountsAPI, but supported by accounts API and HTTPClient.
+func (c *Client) Patch(ctx context.Context, acc *account.Account) (*account.Account, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		return nil, errors.New("cannot patch account without initialized account object. Use account builder")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	requestURL := endpoint + "/" + acc.ID()
+	stored, exists := c.accounts[acc.ID()]
+	if !exists {
+		return nil, apiError(http.MethodPatch, requestURL, http.StatusNotFound,
+			fmt.Sprintf("record %s does not exist", acc.ID()))
+	}
+	if stored.Version() != acc.Version() {
+		return nil, conflictError(http.MethodPatch, requestURL, account.ErrorCodeVersionMismatch, "invalid version")
+	}
+	patched := stamped(acc, stored.Version()+1, stored.CreatedOn(), c.now().UTC())
+	c.accounts[acc.ID()] = patched
+	return clone(patched), nil
+}
+
+// Delete removes stored account if provided version is the current one.
+func (c *Client) Delete(ctx context.Context, accountID string, version int) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(accountID); err != nil {
+		return errors.Wrap(err, "provided account ID must be a valid UUID")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	requestURL := endpoint + "/" + accountID + "?version=" + strconv.Itoa(version)
+	stored, exists := c.accounts[accountID]
+	if !exists {
+		return apiError(http.MethodDelete, requestURL, http.StatusNotFound, "")
+	}
+	if stored.Version() != version {
+		return conflictError(http.MethodDelete, requestURL, account.ErrorCodeVersionMismatch, "invalid version")
+	}
+	delete(c.accounts, accountID)
+	for i, id := range c.order {
+		if id == accountID {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// IsHealthy returns health status set with SetHealthy. Fake is healthy by default.
+func (c *Client) IsHealthy(ctx context.Context) bool {
+	if contextError(ctx) != nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.healthy
+}
+
+// slices accounts the same way API handles page[number] and page[size] parameters.
+func page(accounts []account.Account, paging *account.PaginationSettings) ([]account.Account, error) {
+	size := paging.PageSize
+	if size < 1 {
+		size = defaultPageSize
+	}
+	last := 0
+	if len(accounts) > 0 {
+		last = (len(accounts) - 1) / size
+	}
+	var number int
+	switch paging.PageNumber {
+	case "first", "":
+		number = 0
+	case "last":
+		number = last
+	default:
+		n, err := strconv.Atoi(paging.PageNumber)
+		if err != nil || n < 0 {
+			return nil, apiError(http.MethodGet, endpoint, http.StatusBadRequest, "invalid page number")
+		}
+		number = n
+	}
+	from := number * size
+	if from >= len(accounts) {
+		return make([]account.Account, 0), nil
+	}
+	to := from + size
+	if to > len(accounts) {
+		to = len(accounts)
+	}
+	return accounts[from:to], nil
+}
+
+func matches(acc *account.Account, filter account.Filter) bool {
+	return (filter.Country == "" || filter.Country.Code() == acc.Country()) &&
+		(filter.BankID == "" || filter.BankID == acc.BankID()) &&
+		(filter.BankIDCode == "" || filter.BankIDCode == acc.BankIDCode()) &&
+		(filter.AccountNumber == "" || filter.AccountNumber == acc.AccountNumber()) &&
+		(filter.Iban == "" || filter.Iban == acc.Iban()) &&
+		(filter.CustomerID == "" || filter.CustomerID == acc.CustomerID())
+}
+
+func apiError(method, url string, status int, message string) *account.APIError {
+	body := ""
+	if message != "" {
+		body = fmt.Sprintf(`{"error_message":%q}`, message)
+	}
+	return &account.APIError{
+		StatusCode:   status,
+		ErrorMessage: message,
+		Method:       method,
+		URL:          url,
+		Body:         []byte(body),
+	}
+}
+
+// conflicts carry error_code, so they are matched by it rather than by error_message wording.
+func conflictError(method, url, code, message string) *account.APIError {
+	apiErr := apiError(method, url, http.StatusConflict, message)
+	apiErr.ErrorCode = code
+	apiErr.Body = []byte(fmt.Sprintf(`{"error_code":%q,"error_message":%q}`, code, message))
+	return apiErr
+}
+
+// accounts are handed out as copies, so callers cannot modify stored ones.
+func clone(acc *account.Account) *account.Account {
+	return stamped(acc, acc.Version(), acc.CreatedOn(), acc.ModifiedOn())
+}
+
+// returns a copy of account with server managed attributes replaced.
+func stamped(acc *account.Account, version int, createdOn, modifiedOn time.Time) *account.Account {
+	return stamp.Account(acc, version, createdOn, modifiedOn).(*account.Account)
+}
+
+func contextError(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,380 @@
+// Package accountserver provides a local stand-in for Form3 accounts API.
+// It serves /v1/organisation/accounts and /v1/health endpoints over HTTP without docker, postgres or vault,
+// so HTTPClient can be pointed at it in tests:
+//
+//	server := httptest.NewServer(accountserver.New())
+//	defer server.Close()
+//	client, err := account.NewHTTPClient(nil, server.URL, accountserver.AccountsEndpoint)
+//
+// Accounts are stored in memory by accountfake.Client, hence the same conflict, not found and version semantics apply.
+// Submitted accounts are validated with account Builder rules.
+package accountserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/text/currency"
+
+	account "github.com/r0kas/form3-accountapi-client"
+	"github.com/r0kas/form3-accountapi-client/accountfake"
+)
+
+const (
+	// AccountsEndpoint is the path accounts resource is served on.
+	AccountsEndpoint = "/v1/organisation/accounts"
+	// HealthEndpoint is the path health status is served on.
+	HealthEndpoint = "/v1/health"
+
+	contentType     = "application/vnd.api+json"
+	defaultPageSize = 100
+)
+
+// Server handles accounts API requests. Use New to create one.
+type Server struct {
+	store *accountfake.Client
+}
+
+// New creates empty and healthy accounts API stand-in.
+func New() *Server {
+	return &Server{
+		store: accountfake.New(),
+	}
+}
+
+// SetHealthy changes status reported by health endpoint.
+func (s *Server) SetHealthy(healthy bool) *Server {
+	s.store.SetHealthy(healthy)
+	return s
+}
+
+// ServeHTTP routes request to accounts or health handlers.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == HealthEndpoint:
+		s.health(w, r)
+	case r.URL.Path == AccountsEndpoint:
+		s.accounts(w, r)
+	case strings.HasPrefix(r.URL.Path, AccountsEndpoint+"/"):
+		s.account(w, r, strings.TrimPrefix(r.URL.Path, AccountsEndpoint+"/"))
+	default:
+		writeError(w, http.StatusNotFound, "resource not found")
+	}
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !s.store.IsHealthy(r.Context()) {
+		writeJSON(w, http.StatusServiceUnavailable, healthResponse{Status: "down"})
+		return
+	}
+	writeJSON(w, http.StatusOK, healthResponse{Status: "up"})
+}
+
+// handles collection requests - list and create.
+func (s *Server) accounts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.list(w, r)
+	case http.MethodPost:
+		acc, err := accountFromRequest(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		created, err := s.store.Create(r.Context(), acc)
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, resource{Data: dataFrom(created), Links: &links{Self: r.URL.Path + "/" + created.ID()}})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// handles single account requests - fetch, patch and delete.
+func (s *Server) account(w http.ResponseWriter, r *http.Request, id string) {
+	if _, err := uuid.Parse(id); err != nil {
+		writeError(w, http.StatusBadRequest, "id is not a valid uuid")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		fetched, err := s.store.Fetch(r.Context(), id)
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, resource{Data: dataFrom(fetched), Links: &links{Self: r.URL.Path}})
+	case http.MethodPatch:
+		acc, err := accountFromRequest(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if acc.ID() != id {
+			writeError(w, http.StatusBadRequest, "id in body does not match id in path")
+			return
+		}
+		patched, err := s.store.Patch(r.Context(), acc)
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, resource{Data: dataFrom(patched), Links: &links{Self: r.URL.Path}})
+	case http.MethodDelete:
+		version, err := strconv.Atoi(r.URL.Query().Get("version"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid version number")
+			return
+		}
+		if err := s.store.Delete(r.Context(), id, version); err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// lists accounts page by page, responding with pagination links the same way accounts API does.
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	size := defaultPageSize
+	if value := query.Get("page[size]"); value != "" {
+		var err error
+		if size, err = strconv.Atoi(value); err != nil || size < 1 {
+			writeError(w, http.StatusBadRequest, "invalid page size")
+			return
+		}
+	}
+	filter := filterFrom(query)
+	accounts, err := s.store.List(r.Context(), nil, filter)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	last := 0
+	if len(accounts) > 0 {
+		last = (len(accounts) - 1) / size
+	}
+	number := 0
+	switch value := query.Get("page[number]"); value {
+	case "", "first":
+	case "last":
+		number = last
+	default:
+		if number, err = strconv.Atoi(value); err != nil || number < 0 {
+			writeError(w, http.StatusBadRequest, "invalid page number")
+			return
+		}
+	}
+
+	data := make([]accountData, 0)
+	for i := number * size; i < len(accounts) && i < (number+1)*size; i++ {
+		data = append(data, dataFrom(&accounts[i]))
+	}
+	pageLink := func(page string) string {
+		linkQuery := url.Values{}
+		for key, values := range query {
+			if strings.HasPrefix(key, "filter[") {
+				linkQuery[key] = values
+			}
+		}
+		linkQuery.Set("page[number]", page)
+		linkQuery.Set("page[size]", strconv.Itoa(size))
+		return r.URL.Path + "?" + linkQuery.Encode()
+	}
+	pageLinks := &links{
+		Self:  pageLink(strconv.Itoa(number)),
+		First: pageLink("first"),
+		Last:  pageLink("last"),
+	}
+	if number < last {
+		pageLinks.Next = pageLink(strconv.Itoa(number + 1))
+	}
+	if number > 0 && number <= last {
+		pageLinks.Prev = pageLink(strconv.Itoa(number - 1))
+	}
+	writeJSON(w, http.StatusOK, resourceList{Data: data, Links: pageLinks})
+}
+
+// decodes account from request body and validates it with account Builder rules.
+func accountFromRequest(r *http.Request) (*account.Account, error) {
+	body := resource{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, errors.Wrap(err, "invalid request body")
+	}
+	data := body.Data
+	if data.Type != "accounts" {
+		return nil, errors.New("type must be accounts")
+	}
+	if _, err := uuid.Parse(data.ID); err != nil {
+		return nil, errors.New("id is not a valid uuid")
+	}
+	if _, err := uuid.Parse(data.OrganisationID); err != nil {
+		return nil, errors.New("organisation_id is not a valid uuid")
+	}
+	attrs := data.Attributes
+	country := account.Country(attrs.Country)
+	if attrs.BankIDCode != "" && attrs.BankIDCode != country.BankIDCode() {
+		return nil, errors.Errorf("bank_id_code %s is not valid for country %s", attrs.BankIDCode, attrs.Country)
+	}
+	if attrs.Iban != "" && !validIbanFormat(attrs.Iban) {
+		return nil, errors.New("iban in body should match '^[A-Z]{2}[0-9]{2}[A-Z0-9]{0,64}$'")
+	}
+
+	builder := account.NewBuilder(country).
+		SetID(data.ID).
+		SetOrganizationID(data.OrganisationID).
+		SetBankID(attrs.BankID).
+		SetBic(attrs.Bic).
+		SetIban(attrs.Iban)
+	if attrs.BaseCurrency != "" {
+		unit, err := currency.ParseISO(attrs.BaseCurrency)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid base_currency")
+		}
+		builder.SetOptionalAttribute().SetBaseCurrency(unit)
+	}
+	if attrs.AccountClassification != "" {
+		builder.SetOptionalAttribute().SetAccountClassification(attrs.AccountClassification)
+	}
+	builder.SetOptionalAttribute().SetVersion(data.Version).
+		SetOptionalAttribute().SetAccountNumber(attrs.AccountNumber).
+		SetOptionalAttribute().SetCustomerID(attrs.CustomerID).
+		SetOptionalAttribute().SetTitle(attrs.Title).
+		SetOptionalAttribute().SetFirstName(attrs.FirstName).
+		SetOptionalAttribute().SetBankAccountName(attrs.BankAccountName).
+		SetOptionalAttribute().SetAltBankAccountNames(attrs.AltBankAccountNames...).
+		SetOptionalAttribute().SetJointAccount(attrs.JointAccount).
+		SetOptionalAttribute().SetAccountMatchingOptOut(attrs.AccountMatchingOptOut).
+		SetOptionalAttribute().SetSecondaryIdentification(attrs.SecondaryIdentification).
+		SetOptionalAttribute().SetName(attrs.Name...).
+		SetOptionalAttribute().SetAlternativeNames(attrs.AlternativeNames...).
+		SetOptionalAttribute().SetStatus(attrs.Status).
+		SetOptionalAttribute().SetStatusReason(attrs.StatusReason).
+		SetOptionalAttribute().SetSwitched(attrs.Switched).
+		SetOptionalAttribute().SetUserDefinedData(attrs.UserDefinedData...).
+		SetOptionalAttribute().SetValidationType(attrs.ValidationType).
+		SetOptionalAttribute().SetReferenceMask(attrs.ReferenceMask).
+		SetOptionalAttribute().SetAcceptanceQualifier(attrs.AcceptanceQualifier).
+		SetOptionalAttribute().SetProcessingService(attrs.ProcessingService)
+	if attrs.PrivateIdentification != nil {
+		builder.SetOptionalAttribute().SetPrivateIdentification(*attrs.PrivateIdentification)
+	}
+	if attrs.OrganisationIdentification != nil {
+		builder.SetOptionalAttribute().SetOrganisationIdentification(*attrs.OrganisationIdentification)
+	}
+	return builder.Validate()
+}
+
+func validIbanFormat(iban string) bool {
+	if len(iban) < 4 || len(iban) > 68 {
+		return false
+	}
+	for i, char := range iban {
+		isLetter := char >= 'A' && char <= 'Z'
+		isDigit := char >= '0' && char <= '9'
+		if (i < 2 && !isLetter) || (i >= 2 && i < 4 && !isDigit) || (i >= 4 && !isLetter && !isDigit) {
+			return false
+		}
+	}
+	return true
+}
+
+func filterFrom(query url.Values) account.Filter {
+	return account.Filter{
+		Country:       account.Country(query.Get("filter[country]")),
+		BankID:        query.Get("filter[bank_id]"),
+		BankIDCode:    query.Get("filter[bank_id_code]"),
+		AccountNumber: query.Get("filter[account_number]"),
+		Iban:          query.Get("filter[iban]"),
+		CustomerID:    query.Get("filter[customer_id]"),
+	}
+}
+
+func dataFrom(acc *account.Account) accountData {
+	createdOn, modifiedOn := acc.CreatedOn(), acc.ModifiedOn()
+	return accountData{
+		Type:           "accounts",
+		ID:             acc.ID(),
+		OrganisationID: acc.OrganizationID(),
+		Version:        acc.Version(),
+		CreatedOn:      &createdOn,
+		ModifiedOn:     &modifiedOn,
+		Attributes: attributes{
+			Country:                    acc.Country(),
+			BaseCurrency:               acc.BaseCurrency(),
+			BankID:                     acc.BankID(),
+			BankIDCode:                 acc.BankIDCode(),
+			AccountNumber:              acc.AccountNumber(),
+			Bic:                        acc.Bic(),
+			Iban:                       acc.Iban(),
+			CustomerID:                 acc.CustomerID(),
+			Title:                      acc.Title(),
+			FirstName:                  acc.FirstName(),
+			BankAccountName:            acc.BankAccountName(),
+			AltBankAccountNames:        acc.AltBankAccountNames(),
+			AccountClassification:      acc.AccountClassification(),
+			JointAccount:               acc.IsJointAccount(),
+			AccountMatchingOptOut:      acc.IsAccountMatchingOptOut(),
+			SecondaryIdentification:    acc.SecondaryIdentification(),
+			Name:                       acc.Name(),
+			AlternativeNames:           acc.AlternativeNames(),
+			Status:                     acc.Status(),
+			StatusReason:               acc.StatusReason(),
+			Switched:                   acc.IsSwitched(),
+			UserDefinedData:            acc.UserDefinedData(),
+			ValidationType:             acc.ValidationType(),
+			ReferenceMask:              acc.ReferenceMask(),
+			AcceptanceQualifier:        acc.AcceptanceQualifier(),
+			ProcessingService:          acc.ProcessingService(),
+			PrivateIdentification:      acc.PrivateIdentification(),
+			OrganisationIdentification: acc.OrganisationIdentification(),
+		},
+	}
+}
+
+// translates accountfake errors into API responses.
+func writeStoreError(w http.ResponseWriter, err error) {
+	apiErr := &account.APIError{}
+	if errors.As(err, &apiErr) {
+		if apiErr.ErrorCode != "" {
+			writeJSON(w, apiErr.StatusCode, errorResponse{ErrorCode: apiErr.ErrorCode, ErrorMessage: apiErr.ErrorMessage})
+			return
+		}
+		writeError(w, apiErr.StatusCode, apiErr.ErrorMessage)
+		return
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	if message == "" {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, errorResponse{ErrorMessage: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,273 @@
+package account
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// bbanParts are the account attributes BBAN is built from.
+type bbanParts struct {
+	bankID        string
+	accountNumber string
+	bic           string
+}
+
+///////
+// Every country composes its BBAN differently - some take bank code from BIC,
+// some append national check digits which are not part of the account number Form3 stores.
+// Where national check digits can be part of the account number, both forms are accepted
+// and missing check digits are calculated.
+///////
+var bbanBuilders = map[Country]func(bbanParts) (string, error){
+	UnitedKingdom: func(p bbanParts) (string, error) {
+		if len(p.bic) < 4 {
+			return "", errors.New("BIC is required to derive IBAN in GB")
+		}
+		if err := requireDigits("bank ID", p.bankID, 6); err != nil {
+			return "", err
+		}
+		if err := requireDigits("account number", p.accountNumber, 8); err != nil {
+			return "", err
+		}
+		return p.bic[:4] + p.bankID + p.accountNumber, nil
+	},
+	Belgium: func(p bbanParts) (string, error) {
+		if err := requireDigits("bank ID", p.bankID, 3); err != nil {
+			return "", err
+		}
+		if len(p.accountNumber) == 9 {
+			return p.bankID + p.accountNumber, requireDigits("account number", p.accountNumber, 9)
+		}
+		if err := requireDigits("account number", p.accountNumber, 7); err != nil {
+			return "", err
+		}
+		return p.bankID + p.accountNumber + belgianCheckDigits(p.bankID+p.accountNumber), nil
+	},
+	France: func(p bbanParts) (string, error) {
+		if err := requireDigits("bank ID", p.bankID, 10); err != nil {
+			return "", err
+		}
+		if len(p.accountNumber) == 13 {
+			return p.bankID + p.accountNumber, requirePattern("account number", p.accountNumber, frenchAccountNumberWithKey)
+		}
+		if err := requirePattern("account number", p.accountNumber, frenchAccountNumber); err != nil {
+			return "", err
+		}
+		return p.bankID + p.accountNumber + ribKey(p.bankID[:5], p.bankID[5:], p.accountNumber), nil
+	},
+	Germany: func(p bbanParts) (string, error) {
+		if err := requireDigits("bank ID", p.bankID, 8); err != nil {
+			return "", err
+		}
+		accountNumber, err := padDigits("account number", p.accountNumber, 10)
+		if err != nil {
+			return "", err
+		}
+		return p.bankID + accountNumber, nil
+	},
+	Greece: func(p bbanParts) (string, error) {
+		if err := requireDigits("bank ID", p.bankID, 7); err != nil {
+			return "", err
+		}
+		if err := requirePattern("account number", p.accountNumber, alphanumeric(16)); err != nil {
+			return "", err
+		}
+		return p.bankID + p.accountNumber, nil
+	},
+	Italy: func(p bbanParts) (string, error) {
+		if err := requireDigits("bank ID", p.bankID, 10); err != nil {
+			return "", errors.Wrap(err, "IBAN derivation in IT requires ABI and CAB codes")
+		}
+		if err := requirePattern("account number", p.accountNumber, alphanumeric(12)); err != nil {
+			return "", err
+		}
+		return cin(p.bankID+p.accountNumber) + p.bankID + p.accountNumber, nil
+	},
+	Luxembourg: func(p bbanParts) (string, error) {
+		if err := requireDigits("bank ID", p.bankID, 3); err != nil {
+			return "", err
+		}
+		if err := requirePattern("account number", p.accountNumber, alphanumeric(13)); err != nil {
+			return "", err
+		}
+		return p.bankID + p.accountNumber, nil
+	},
+	Netherlands: func(p bbanParts) (string, error) {
+		if len(p.bic) < 4 {
+			return "", errors.New("BIC is required to derive IBAN in NL")
+		}
+		accountNumber, err := padDigits("account number", p.accountNumber, 10)
+		if err != nil {
+			return "", err
+		}
+		return p.bic[:4] + accountNumber, nil
+	},
+	Poland: func(p bbanParts) (string, error) {
+		if err := requireDigits("bank ID", p.bankID, 8); err != nil {
+			return "", err
+		}
+		if err := requireDigits("account number", p.accountNumber, 16); err != nil {
+			return "", err
+		}
+		return p.bankID + p.accountNumber, nil
+	},
+	Portugal: func(p bbanParts) (string, error) {
+		if err := requireDigits("bank ID", p.bankID, 8); err != nil {
+			return "", err
+		}
+		if len(p.accountNumber) == 13 {
+			return p.bankID + p.accountNumber, requireDigits("account number", p.accountNumber, 13)
+		}
+		if err := requireDigits("account number", p.accountNumber, 11); err != nil {
+			return "", err
+		}
+		return p.bankID + p.accountNumber + nibCheckDigits(p.bankID+p.accountNumber), nil
+	},
+	Spain: func(p bbanParts) (string, error) {
+		if err := requireDigits("bank ID", p.bankID, 8); err != nil {
+			return "", err
+		}
+		if len(p.accountNumber) == 12 {
+			return p.bankID + p.accountNumber, requireDigits("account number", p.accountNumber, 12)
+		}
+		if err := requireDigits("account number", p.accountNumber, 10); err != nil {
+			return "", err
+		}
+		return p.bankID + cccCheckDigits(p.bankID, p.accountNumber) + p.accountNumber, nil
+	},
+	Switzerland: func(p bbanParts) (string, error) {
+		if err := requireDigits("bank ID", p.bankID, 5); err != nil {
+			return "", err
+		}
+		if isDigits(p.accountNumber) {
+			accountNumber, err := padDigits("account number", p.accountNumber, 12)
+			return p.bankID + accountNumber, err
+		}
+		if err := requirePattern("account number", p.accountNumber, alphanumeric(12)); err != nil {
+			return "", err
+		}
+		return p.bankID + p.accountNumber, nil
+	},
+}
+
+var (
+	frenchAccountNumber        = alphanumeric(11)
+	frenchAccountNumberWithKey = regexp.MustCompile(`^[A-Z0-9]{11}[0-9]{2}$`)
+	// A-I, J-R and S-Z are converted to 1-9, 1-9 and 2-9 respectively
+	ribLetterDigits = "12345678912345678923456789"
+)
+
+// belgianCheckDigits returns 2 national check digits of 10 digit Belgian account number: mod 97, with 97 instead of 0.
+func belgianCheckDigits(digits string) string {
+	remainder := ibanMod97(digits)
+	if remainder == 0 {
+		remainder = 97
+	}
+	return twoDigits(remainder)
+}
+
+// ribKey returns 2 digit French RIB key of bank code, branch code and account number.
+// Letters of the account number are converted to digits as defined by the French banking standard.
+func ribKey(bankCode, branchCode, accountNumber string) string {
+	var digits strings.Builder
+	for _, r := range accountNumber {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteByte(ribLetterDigits[r-'A'])
+			continue
+		}
+		digits.WriteRune(r)
+	}
+	remainder := (89*ibanMod97(bankCode) + 15*ibanMod97(branchCode) + 3*ibanMod97(digits.String())) % 97
+	return twoDigits(97 - remainder)
+}
+
+// nibCheckDigits returns 2 check digits of Portuguese NIB.
+func nibCheckDigits(digits string) string {
+	return twoDigits(98 - ibanMod97(digits+"00"))
+}
+
+// cccCheckDigits returns 2 check digits of Spanish CCC: first one of bank and branch codes, second one of account number.
+func cccCheckDigits(bankID, accountNumber string) string {
+	return strconv.Itoa(cccDigit("00"+bankID)) + strconv.Itoa(cccDigit(accountNumber))
+}
+
+// digits must be at most 10, as many as there are weights.
+func cccDigit(digits string) int {
+	weights := []int{1, 2, 4, 8, 5, 10, 9, 7, 3, 6}
+	sum := 0
+	for i, r := range digits {
+		sum += int(r-'0') * weights[i]
+	}
+	digit := 11 - sum%11
+	switch digit {
+	case 11:
+		return 0
+	case 10:
+		return 1
+	}
+	return digit
+}
+
+// cin returns Italian check character of ABI, CAB and account number.
+func cin(s string) string {
+	odd := []int{1, 0, 5, 7, 9, 13, 15, 17, 19, 21, 2, 4, 18, 20, 11, 3, 6, 8, 12, 14, 16, 10, 22, 25, 24, 23}
+	sum := 0
+	for i, r := range s {
+		value := int(r - 'A')
+		if r >= '0' && r <= '9' {
+			value = int(r - '0')
+		}
+		// positions are counted from 1, so even index is an odd position
+		if i%2 == 0 {
+			sum += odd[value]
+		} else {
+			sum += value
+		}
+	}
+	return string(rune('A' + sum%26))
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+func alphanumeric(length int) *regexp.Regexp {
+	return regexp.MustCompile(`^[A-Z0-9]{` + strconv.Itoa(length) + `}$`)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func requireDigits(name, value string, length int) error {
+	if len(value) != length || !isDigits(value) {
+		return errors.Errorf("%s must be %d digits to derive IBAN, got %q", name, length, value)
+	}
+	return nil
+}
+
+func requirePattern(name, value string, pattern *regexp.Regexp) error {
+	if !pattern.MatchString(value) {
+		return errors.Errorf("%s must match %s to derive IBAN, got %q", name, pattern, value)
+	}
+	return nil
+}
+
+// left pads numeric value with zeros, as national formats do not require leading zeros.
+func padDigits(name, value string, length int) (string, error) {
+	if !isDigits(value) || len(value) > length {
+		return "", errors.Errorf("%s must be up to %d digits to derive IBAN, got %q", name, length, value)
+	}
+	return strings.Repeat("0", length-len(value)) + value, nil
+}
@@ -0,0 +1,95 @@
+package account
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// BIC represents ISO 9362 Business Identifier Code (SWIFT code), e.g. 'NWBKGB22' or 'NWBKGB22XXX'.
+// Value of Account.Bic() can be converted to it directly: BIC(acc.Bic()).
+///////
+// BIC is a string type like Country, so it can be compared and printed as is,
+// while accessors give names to the parts instead of slicing them by index at every call site.
+///////
+type BIC string
+
+var bicFormat = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+
+// ParseBIC validates BIC structure: 4 letter institution code, 2 letter country code,
+// 2 character location code and optional 3 character branch code.
+func ParseBIC(bic string) (BIC, error) {
+	if !bicFormat.MatchString(bic) {
+		return "", errors.Errorf("BIC %s must consist of 4 letter institution code, 2 letter country code, "+
+			"2 character location code and optional 3 character branch code", bic)
+	}
+	return BIC(bic), nil
+}
+
+// Validate checks BIC structure, see ParseBIC.
+func (bic BIC) Validate() error {
+	_, err := ParseBIC(string(bic))
+	return err
+}
+
+// InstitutionCode returns 4 letter code of the bank, e.g. 'NWBK'.
+func (bic BIC) InstitutionCode() string {
+	return bic.part(0, 4)
+}
+
+// Country returns country the bank is located in, e.g. 'GB'.
+func (bic BIC) Country() Country {
+	return Country(bic.part(4, 6))
+}
+
+// LocationCode returns 2 character location code, e.g. '22'.
+func (bic BIC) LocationCode() string {
+	return bic.part(6, 8)
+}
+
+// BranchCode returns 3 character branch code. Empty for 8 character BIC.
+func (bic BIC) BranchCode() string {
+	return bic.part(8, 11)
+}
+
+// IsPrimaryOffice tells if BIC identifies primary office of the bank - it has no branch code or branch code is 'XXX'.
+func (bic BIC) IsPrimaryOffice() bool {
+	branch := bic.BranchCode()
+	return branch == "" || branch == "XXX"
+}
+
+// String returns BIC as is.
+func (bic BIC) String() string {
+	return string(bic)
+}
+
+// returns part of BIC or empty string if BIC is too short to have it.
+func (bic BIC) part(from, to int) string {
+	if len(bic) < to {
+		return ""
+	}
+	return string(bic[from:to])
+}
+
+// BIC validation rules reported in ValidationError.
+const (
+	bicFormatRule  = "bic_format"
+	bicCountryRule = "bic_country"
+)
+
+// validateBic checks BIC structure and that it belongs to the account country.
+// Returns violated rule together with the error.
+// Empty BIC is valid, as it is required only in some countries, which is checked with country validation tags.
+func validateBic(country Country, bic string) (string, error) {
+	if bic == "" {
+		return "", nil
+	}
+	parsed, err := ParseBIC(bic)
+	if err != nil {
+		return bicFormatRule, err
+	}
+	if parsed.Country() != country {
+		return bicCountryRule, errors.Errorf("BIC %s country code %s does not match account country %s", bic, parsed.Country(), country.Code())
+	}
+	return "", nil
+}
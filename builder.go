@@ -17,23 +17,27 @@ type (
 	// Also builder is the only way of creating and setting account objects, which mitigates the misuse of SDK.
 	///////
 	Builder struct {
-		essential *essentialAttributes
-		optional  *optionalAttributes
-		validate  *validator.Validate
+		essential  *essentialAttributes
+		optional   *optionalAttributes
+		validate   *validator.Validate
+		deriveIban bool
+		locale     Locale
+		// unknown JSON of the account the builder was cast from
+		extra Extra
 	}
 
 	///////
-	// Validation tags are based on country codes, hence it's easy to differentiate and
-	// also trivial to set the validator tag as country code is the core attribute of account builder.
-	//////
+	// Country specific rules are kept in the country registry rather than in struct tags,
+	// so countries can be registered at runtime. Tags here hold rules common to all countries.
+	///////
 	essentialAttributes struct {
-		ID             string `validate:"uuid,required"`
-		OrganizationID string `validate:"uuid,required"`
-		Country        string `validate:"eq=GB|eq=AU|eq=BE|eq=CA|eq=FR|eq=DE|eq=GR|eq=HK|eq=IT|eq=LU|eq=NL|eq=PL|eq=PT|eq=ES|eq=ES|eq=CH|eq=US"`
-		BankIDCode     string
-		BankID         string `GB:"len=6" BE:"len=3" FR:"len=10" DE:"len=8" GR:"len=7" IT:"len=10|len=11" LU:"len=3" NL:"len=0" PL:"len=8" PT:"len=8" ES:"len=8" CH:"len=5" US:"len=9"`
-		Bic            string `GB:"len=8|len=11" AU:"len=8|len=11" CA:"len=8|len=11" HK:"len=8|len=11" NL:"len=8|len=11" US:"len=8|len=11"`
-		Iban           string `AU:"len=0" CA:"len=0" HK:"len=0" US:"len=0"`
+		ID             string `validate:"uuid,required" setter:"SetID"`
+		OrganizationID string `validate:"uuid,required" setter:"SetOrganizationID"`
+		Country        string `setter:"NewBuilder"`
+		BankIDCode     string `setter:"NewBuilder"`
+		BankID         string `setter:"SetBankID"`
+		Bic            string `setter:"SetBic"`
+		Iban           string `setter:"SetIban"`
 	}
 
 	///////
@@ -41,19 +45,31 @@ type (
 	// Having a structure and separating optional attributes from essential ones lowers cognitive load for client user.
 	///////
 	optionalAttributes struct {
-		Builder                 *Builder
-		VersionIndex            int
-		AccountNumber           string
-		BaseCurrency            string
-		CustomerID              string
-		Title                   string   `validate:"max=40"`
-		FirstName               string   `validate:"max=40"`
-		BankAccountName         string   `validate:"max=140"`
-		AltBankAccountNames     []string `validate:"max=3,dive,max=140"`
-		AccountClassification   string   `validate:"eq=Personal|eq=Business"`
-		SecondaryIdentification string   `validate:"max=140"`
-		JointAccount            bool
-		AccountMatchingOptOut   bool
+		Builder                    *Builder
+		VersionIndex               int                         `setter:"SetVersion"`
+		AccountNumber              string                      `setter:"SetAccountNumber"`
+		BaseCurrency               string                      `setter:"SetBaseCurrency"`
+		CustomerID                 string                      `setter:"SetCustomerID"`
+		Title                      string                      `validate:"max=40" setter:"SetTitle"`
+		FirstName                  string                      `validate:"max=40" setter:"SetFirstName"`
+		BankAccountName            string                      `validate:"max=140" setter:"SetBankAccountName"`
+		AltBankAccountNames        []string                    `validate:"max=3,dive,max=140" setter:"SetAltBankAccountNames"`
+		AccountClassification      string                      `validate:"eq=Personal|eq=Business" setter:"SetAccountClassification"`
+		SecondaryIdentification    string                      `validate:"max=140" setter:"SetSecondaryIdentification"`
+		JointAccount               bool                        `setter:"SetJointAccount"`
+		AccountMatchingOptOut      bool                        `setter:"SetAccountMatchingOptOut"`
+		Name                       []string                    `validate:"max=4,dive,max=140" setter:"SetName"`
+		AlternativeNames           []string                    `validate:"max=3,dive,max=140" setter:"SetAlternativeNames"`
+		Status                     string                      `validate:"omitempty,eq=pending|eq=confirmed|eq=closed" setter:"SetStatus"`
+		StatusReason               string                      `validate:"max=140" setter:"SetStatusReason"`
+		Switched                   bool                        `setter:"SetSwitched"`
+		UserDefinedData            []UserDefinedData           `validate:"dive" setter:"SetUserDefinedData"`
+		ValidationType             string                      `validate:"omitempty,eq=card" setter:"SetValidationType"`
+		ReferenceMask              string                      `validate:"max=35" setter:"SetReferenceMask"`
+		AcceptanceQualifier        string                      `validate:"omitempty,eq=same_day|eq=next_day" setter:"SetAcceptanceQualifier"`
+		ProcessingService          string                      `validate:"max=35" setter:"SetProcessingService"`
+		PrivateIdentification      *PrivateIdentification      `setter:"SetPrivateIdentification"`
+		OrganisationIdentification *OrganisationIdentification `setter:"SetOrganisationIdentification"`
 	}
 	// OptionalAttributes has a collection of methods to set optional account attributes
 	///////
@@ -72,24 +88,49 @@ type (
 		SetJointAccount(bool) *Builder
 		SetAccountMatchingOptOut(bool) *Builder
 		SetSecondaryIdentification(string) *Builder
+		SetName(...string) *Builder
+		SetAlternativeNames(...string) *Builder
+		SetStatus(string) *Builder
+		SetStatusReason(string) *Builder
+		SetSwitched(bool) *Builder
+		SetUserDefinedData(...UserDefinedData) *Builder
+		SetValidationType(string) *Builder
+		SetReferenceMask(string) *Builder
+		SetAcceptanceQualifier(string) *Builder
+		SetProcessingService(string) *Builder
+		SetPrivateIdentification(PrivateIdentification) *Builder
+		SetOrganisationIdentification(OrganisationIdentification) *Builder
 	}
 )
 
 // NewBuilder creates account builder from provided Country.
-// Country sets validation rules for created accounts.
+// Country sets validation rules for created accounts and default base currency, see CountryRules.
 func NewBuilder(country Country) *Builder {
+	rules, _ := country.Rules()
+	optional := &optionalAttributes{
+		AccountClassification: "Personal",
+	}
+	if rules.Currency != (currency.Unit{}) {
+		optional.BaseCurrency = rules.Currency.String()
+	}
 	return &Builder{
 		essential: &essentialAttributes{
 			Country:    country.Code(),
-			BankIDCode: country.BankIDCode(),
-		},
-		optional: &optionalAttributes{
-			AccountClassification: "Personal",
+			BankIDCode: rules.BankIDCode,
 		},
-		validate: validator.New(),
+		optional: optional,
+		validate: newValidator(),
 	}
 }
 
+// NewBuilderE creates account builder like NewBuilder, but fails fast if country is not supported.
+func NewBuilderE(country Country) (*Builder, error) {
+	if !country.IsSupported() {
+		return nil, errors.Errorf("country %s is not supported", country.Code())
+	}
+	return NewBuilder(country), nil
+}
+
 // CastBuilderFrom creates an account builder from existing account object.
 // This enables to modify, validate and create new account object.
 ///////
@@ -107,20 +148,33 @@ func CastBuilderFrom(account *Account) *Builder {
 			Iban:           account.Iban(),
 		},
 		optional: &optionalAttributes{
-			VersionIndex:            account.Version(),
-			AccountNumber:           account.AccountNumber(),
-			BaseCurrency:            account.BaseCurrency(),
-			CustomerID:              account.CustomerID(),
-			Title:                   account.Title(),
-			FirstName:               account.FirstName(),
-			BankAccountName:         account.BankAccountName(),
-			AltBankAccountNames:     account.AltBankAccountNames(),
-			AccountClassification:   account.AccountClassification(),
-			SecondaryIdentification: account.SecondaryIdentification(),
-			JointAccount:            account.IsJointAccount(),
-			AccountMatchingOptOut:   account.IsAccountMatchingOptOut(),
+			VersionIndex:               account.Version(),
+			AccountNumber:              account.AccountNumber(),
+			BaseCurrency:               account.BaseCurrency(),
+			CustomerID:                 account.CustomerID(),
+			Title:                      account.Title(),
+			FirstName:                  account.FirstName(),
+			BankAccountName:            account.BankAccountName(),
+			AltBankAccountNames:        account.AltBankAccountNames(),
+			AccountClassification:      account.AccountClassification(),
+			SecondaryIdentification:    account.SecondaryIdentification(),
+			JointAccount:               account.IsJointAccount(),
+			AccountMatchingOptOut:      account.IsAccountMatchingOptOut(),
+			Name:                       account.Name(),
+			AlternativeNames:           account.AlternativeNames(),
+			Status:                     account.Status(),
+			StatusReason:               account.StatusReason(),
+			Switched:                   account.IsSwitched(),
+			UserDefinedData:            account.UserDefinedData(),
+			ValidationType:             account.ValidationType(),
+			ReferenceMask:              account.ReferenceMask(),
+			AcceptanceQualifier:        account.AcceptanceQualifier(),
+			ProcessingService:          account.ProcessingService(),
+			PrivateIdentification:      account.PrivateIdentification(),
+			OrganisationIdentification: account.OrganisationIdentification(),
 		},
-		validate: validator.New(),
+		validate: newValidator(),
+		extra:    account.Extra(),
 	}
 }
 
@@ -137,58 +191,134 @@ func (b *Builder) SetOrganizationID(id string) *Builder {
 }
 
 // SetBankID - Local country bank identifier. Format depends on the country. Required for most countries.
+// US bank ID must be a valid ABA routing number.
 func (b *Builder) SetBankID(bankID string) *Builder {
 	b.essential.BankID = bankID
 	return b
 }
 
 // SetBic - SWIFT BIC in either 8 or 11 character format e.g. 'NWBKGB22'
+// Country code of the BIC must match the builder's country.
 func (b *Builder) SetBic(bic string) *Builder {
 	b.essential.Bic = bic
 	return b
 }
 
 // SetIban - IBAN of the account. Will be calculated from other fields if not supplied.
+// Accepts both print format e.g. 'GB82 WEST 1234 5698 7654 32' and electronic format e.g. 'GB82WEST12345698765432'.
+// Check digits, length and structure are validated for the builder's country, account keeps electronic format.
 func (b *Builder) SetIban(iban string) *Builder {
 	b.essential.Iban = iban
 	return b
 }
 
+// SetLocale sets language of validation error messages returned by Validate. Defaults to English.
+func (b *Builder) SetLocale(locale Locale) *Builder {
+	b.locale = locale
+	return b
+}
+
+// DeriveIban makes Validate calculate IBAN from bank ID, account number and BIC, see DeriveIban function.
+// If IBAN is set as well, it must match the derived one.
+func (b *Builder) DeriveIban() *Builder {
+	b.deriveIban = true
+	return b
+}
+
 // Validate checks set fields based on country code.
 // Returns account object if no errors are generated during validation.
+// Otherwise returns ValidationErrors listing every failing attribute, with messages in the Builder's locale.
 func (b *Builder) Validate() (*Account, error) {
-	b.validate.SetTagName(b.essential.Country)
-	if err := validateStruct(b.validate, b.essential); err != nil {
-		return nil, err
+	country := Country(b.essential.Country)
+
+	var errs ValidationErrors
+	rules, supported := country.Rules()
+	if supported {
+		errs.addVar(country, b.validate, "SetBankID", b.essential.BankID, rules.BankID)
+		errs.addVar(country, b.validate, "SetBic", b.essential.Bic, rules.Bic)
+		errs.addVar(country, b.validate, "SetIban", b.essential.Iban, rules.Iban)
+		errs.addVar(country, b.validate, "SetAccountNumber", b.optional.AccountNumber, rules.AccountNumber)
+	} else {
+		errs.add(country, "NewBuilder", countrySupportedRule, country.Code())
+	}
+	errs.addStruct(country, b.validate, b.essential)
+	errs.addStruct(country, b.validate, b.optional)
+	// format checks are skipped for attributes which already failed country rules, to report each problem once
+	if rule, _ := validateBic(country, b.essential.Bic); rule != "" && !errs.HasField("SetBic") {
+		errs.add(country, "SetBic", rule, b.essential.Bic)
+	}
+	if b.optional.PrivateIdentification != nil && b.optional.OrganisationIdentification != nil {
+		errs.add(country, "SetOrganisationIdentification", identificationRule, "")
 	}
-	b.validate.SetTagName("validate")
-	if err := validateStruct(b.validate, b.essential); err != nil {
-		return nil, err
+	// check digits and modulus checks need both bank ID and account number in the right format
+	accountNumberChecked := b.optional.AccountNumber != "" && !errs.HasField("SetBankID") && !errs.HasField("SetAccountNumber")
+	if accountNumberChecked && rules.AccountNumberCheck != nil {
+		if err := rules.AccountNumberCheck(b.essential.BankID, b.optional.AccountNumber); err != nil {
+			errs.add(country, "SetAccountNumber", checkDigitsRule, b.optional.AccountNumber)
+		}
+	}
+	if table := currentModulusTable(); table != nil && country == UnitedKingdom && accountNumberChecked {
+		if err := table.Check(b.essential.BankID, b.optional.AccountNumber); err != nil {
+			errs.add(country, "SetAccountNumber", modulusRule, b.optional.AccountNumber)
+		}
+	}
+	iban := normaliseIban(b.essential.Iban)
+	if b.deriveIban {
+		derived, err := DeriveIban(country, b.essential.BankID, b.optional.AccountNumber, b.essential.Bic)
+		switch {
+		case err != nil:
+			errs.add(country, "DeriveIban", ibanDerivationRule, iban)
+		case iban != "" && iban != derived:
+			errs.add(country, "SetIban", ibanDerivedRule, iban)
+		default:
+			iban = derived
+		}
 	}
-	if err := validateStruct(b.validate, b.optional); err != nil {
-		return nil, err
+	if rule, _ := validateIban(country, iban); rule != "" && !errs.HasField("SetIban") {
+		errs.add(country, "SetIban", rule, iban)
 	}
+	if len(errs) > 0 {
+		return nil, errs.Localize(b.locale)
+	}
+	return b.account(iban), nil
+}
+
+// creates account from builder attributes with given IBAN, which may be normalised or derived.
+func (b *Builder) account(iban string) *Account {
 	return &Account{
-		id:                      b.essential.ID,
-		organizationID:          b.essential.OrganizationID,
-		versionIndex:            b.optional.VersionIndex,
-		country:                 b.essential.Country,
-		bankIDCode:              b.essential.BankIDCode,
-		bankID:                  b.essential.BankID,
-		bic:                     b.essential.Bic,
-		iban:                    b.essential.Iban,
-		baseCurrency:            b.optional.BaseCurrency,
-		accountNumber:           b.optional.AccountNumber,
-		customerID:              b.optional.CustomerID,
-		title:                   b.optional.Title,
-		firstName:               b.optional.FirstName,
-		bankAccountName:         b.optional.BankAccountName,
-		altBankAccountNames:     b.optional.AltBankAccountNames,
-		accountClassification:   b.optional.AccountClassification,
-		jointAccount:            b.optional.JointAccount,
-		accountMatchingOptOut:   b.optional.AccountMatchingOptOut,
-		secondaryIdentification: b.optional.SecondaryIdentification,
-	}, nil
+		id:                         b.essential.ID,
+		organizationID:             b.essential.OrganizationID,
+		versionIndex:               b.optional.VersionIndex,
+		country:                    b.essential.Country,
+		bankIDCode:                 b.essential.BankIDCode,
+		bankID:                     b.essential.BankID,
+		bic:                        b.essential.Bic,
+		iban:                       iban,
+		baseCurrency:               b.optional.BaseCurrency,
+		accountNumber:              b.optional.AccountNumber,
+		customerID:                 b.optional.CustomerID,
+		title:                      b.optional.Title,
+		firstName:                  b.optional.FirstName,
+		bankAccountName:            b.optional.BankAccountName,
+		altBankAccountNames:        b.optional.AltBankAccountNames,
+		accountClassification:      b.optional.AccountClassification,
+		jointAccount:               b.optional.JointAccount,
+		accountMatchingOptOut:      b.optional.AccountMatchingOptOut,
+		secondaryIdentification:    b.optional.SecondaryIdentification,
+		name:                       b.optional.Name,
+		alternativeNames:           b.optional.AlternativeNames,
+		status:                     b.optional.Status,
+		statusReason:               b.optional.StatusReason,
+		switched:                   b.optional.Switched,
+		userDefinedData:            b.optional.UserDefinedData,
+		validationType:             b.optional.ValidationType,
+		referenceMask:              b.optional.ReferenceMask,
+		acceptanceQualifier:        b.optional.AcceptanceQualifier,
+		processingService:          b.optional.ProcessingService,
+		privateIdentification:      b.optional.PrivateIdentification,
+		organisationIdentification: b.optional.OrganisationIdentification,
+		extra:                      b.extra.clone(),
+	}
 }
 
 // SetOptionalAttribute returns a list of methods for setting optional account attributes.
@@ -199,6 +329,8 @@ func (b *Builder) SetOptionalAttribute() OptionalAttributes {
 }
 
 // SetAccountNumber - A unique account number will automatically be generated if not provided.
+// Format depends on the country, see CountryRules. National check digits are verified when included (BE, FR, PT, ES).
+// GB account numbers are modulus checked together with sort code (bank ID) once table is set with SetModulusTable.
 func (opt *optionalAttributes) SetAccountNumber(accountNumber string) *Builder {
 	opt.Builder.optional.AccountNumber = accountNumber
 	return opt.Builder
@@ -281,12 +413,94 @@ func (opt *optionalAttributes) SetSecondaryIdentification(secondaryID string) *B
 	return opt.Builder
 }
 
-func validateStruct(validate *validator.Validate, s interface{}) (err error) {
-	err = validate.Struct(s)
-	if err != nil {
-		if len(err.(validator.ValidationErrors)) > 0 {
-			return errors.New(err.(validator.ValidationErrors).Error())
-		}
+// SetName - up to 4 lines of account holder's name, used for Confirmation of Payee matching.
+// Each line valid up to string[140]
+func (opt *optionalAttributes) SetName(lines ...string) *Builder {
+	opt.Builder.optional.Name = lines
+	return opt.Builder
+}
+
+// SetAlternativeNames - up to 3 alternative names of account holder, used for Confirmation of Payee matching.
+// Each element valid up to string[140]
+func (opt *optionalAttributes) SetAlternativeNames(names ...string) *Builder {
+	opt.Builder.optional.AlternativeNames = names
+	return opt.Builder
+}
+
+// SetStatus - status of the account: StatusPending, StatusConfirmed or StatusClosed.
+func (opt *optionalAttributes) SetStatus(status string) *Builder {
+	opt.Builder.optional.Status = status
+	return opt.Builder
+}
+
+// SetStatusReason - reason of the account status, e.g. why it is closed.
+// Valid up to string[140]
+func (opt *optionalAttributes) SetStatusReason(reason string) *Builder {
+	opt.Builder.optional.StatusReason = reason
+	return opt.Builder
+}
+
+// SetSwitched - set to True if the account has been switched away from the organisation.
+// Defaults to false.
+func (opt *optionalAttributes) SetSwitched(isSwitched bool) *Builder {
+	opt.Builder.optional.Switched = isSwitched
+	return opt.Builder
+}
+
+// SetUserDefinedData - free-format key value pairs stored with the account.
+// Key is required and valid up to string[50], value up to string[1000]
+func (opt *optionalAttributes) SetUserDefinedData(data ...UserDefinedData) *Builder {
+	opt.Builder.optional.UserDefinedData = data
+	return opt.Builder
+}
+
+// SetValidationType - type of the account validation. Can be 'card' for accounts backing payment cards.
+func (opt *optionalAttributes) SetValidationType(validationType string) *Builder {
+	opt.Builder.optional.ValidationType = validationType
+	return opt.Builder
+}
+
+// SetReferenceMask - mask of payment references accepted by the account, e.g. '############'.
+// Valid up to string[35]
+func (opt *optionalAttributes) SetReferenceMask(mask string) *Builder {
+	opt.Builder.optional.ReferenceMask = mask
+	return opt.Builder
+}
+
+// SetAcceptanceQualifier - when payments to the account are accepted. Can be either 'same_day' or 'next_day'.
+func (opt *optionalAttributes) SetAcceptanceQualifier(qualifier string) *Builder {
+	opt.Builder.optional.AcceptanceQualifier = qualifier
+	return opt.Builder
+}
+
+// SetProcessingService - name of the service processing payments of the account.
+// Valid up to string[35]
+func (opt *optionalAttributes) SetProcessingService(service string) *Builder {
+	opt.Builder.optional.ProcessingService = service
+	return opt.Builder
+}
+
+// SetPrivateIdentification - identification of account holder who is a person.
+// Cannot be set together with organisation identification.
+func (opt *optionalAttributes) SetPrivateIdentification(identification PrivateIdentification) *Builder {
+	opt.Builder.optional.PrivateIdentification = &identification
+	return opt.Builder
+}
+
+// SetOrganisationIdentification - identification of account holder which is an organisation.
+// Cannot be set together with private identification.
+func (opt *optionalAttributes) SetOrganisationIdentification(identification OrganisationIdentification) *Builder {
+	opt.Builder.optional.OrganisationIdentification = &identification
+	return opt.Builder
+}
+
+// validates single value against validator tag, empty tag accepts any value.
+func validateVar(validate *validator.Validate, name, value, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	if err := validate.Var(value, tag); err != nil {
+		return errors.Errorf("%s %s violates '%s' rule", name, value, tag)
 	}
-	return
+	return nil
 }
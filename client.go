@@ -4,27 +4,47 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
+// number of fetch-modify-patch cycles Update makes before giving up on version conflicts
+const updateAttempts = 5
+
 type (
+	// AccountsAPI describes commands available on accounts API.
+	// HTTPClient implements it, accountfake package provides in-memory implementation for tests.
+	///////
+	// Services should depend on this interface rather than on HTTPClient,
+	// so that unit tests do not need a running accounts API.
+	///////
+	AccountsAPI interface {
+		Create(ctx context.Context, account *Account) (*Account, error)
+		Fetch(ctx context.Context, accountID string) (*Account, error)
+		List(ctx context.Context, paging *PaginationSettings, filter ...Filter) ([]Account, error)
+		Delete(ctx context.Context, accountID string, version int) error
+		IsHealthy(ctx context.Context) bool
+	}
+
 	// HTTPClient handles execution and transport of API commands.
 	HTTPClient struct {
-		httpClient  *http.Client
-		apiHost     *url.URL
-		apiEndpoint *url.URL
+		httpClient     *http.Client
+		apiHost        *url.URL
+		apiEndpoint    *url.URL
+		healthPath     *url.URL
+		userAgent      string
+		defaultHeaders http.Header
+		retryPolicy    *RetryPolicy
 	}
 
 	// PaginationSettings represents settings for pagination feature on List command.
+	//
+	// Deprecated: use ListPage with PageRequest, which validates requested page before sending it.
 	PaginationSettings struct {
 		// Enabled turns on pagination functionality in API Client
 		Enabled bool
@@ -35,10 +55,14 @@ type (
 	}
 )
 
+var _ AccountsAPI = (*HTTPClient)(nil)
+
 // NewHTTPClient creates HTTP Client instance.
+// For more configuration options use New.
 ///////
 // Enables SDK user to pre configure http.Client e.g. Timeout settings
 // Validates provided host and endpoint parameters
+// Transient failures are retried using DefaultRetryPolicy - use SetRetryPolicy to change it.
 ///////
 func NewHTTPClient(httpClient *http.Client, apiHost, apiEndpoint string) (*HTTPClient, error) {
 	host, err := url.Parse(apiHost)
@@ -51,13 +75,16 @@ func NewHTTPClient(httpClient *http.Client, apiHost, apiEndpoint string) (*HTTPC
 	}
 	if httpClient == nil {
 		httpClient = &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: defaultTimeout,
 		}
 	}
+	healthPath, _ := url.Parse(defaultHealthPath)
 	return &HTTPClient{
 		httpClient:  httpClient,
 		apiHost:     host,
 		apiEndpoint: endpoint,
+		healthPath:  healthPath,
+		retryPolicy: DefaultRetryPolicy(),
 	}, nil
 }
 
@@ -119,33 +146,123 @@ func (c *HTTPClient) Fetch(ctx context.Context, accountID string) (*Account, err
 
 // List handles execution of list command against accounts API.
 // Accepts context and pagination settings. If pagination settings are not required - parameter can be set to nil.
+// Optionally accepts a single filter which narrows down the list on server side.
 // Returns slice of requested account objects.
 ///////
 // Context adds additional request configuration flexibility for SDK user.
 //////
-func (c *HTTPClient) List(ctx context.Context, paging *PaginationSettings) ([]Account, error) {
+func (c *HTTPClient) List(ctx context.Context, paging *PaginationSettings, filter ...Filter) ([]Account, error) {
 	if err := c.validateClient(); err != nil {
 		return nil, err
 	}
 	if paging == nil {
 		paging = &PaginationSettings{}
 	}
-
-	request, err := c.newRequest(ctx, http.MethodGet, c.clientAPIRequestURL("", c.pagingParameters(paging)), nil)
+	f, err := singleFilter(filter)
 	if err != nil {
 		return nil, err
 	}
 
+	parameters := c.pagingParameters(paging)
+	if f != nil {
+		for key, value := range f.parameters() {
+			parameters[key] = value
+		}
+	}
+	accounts, _, err := c.listPage(ctx, c.clientAPIRequestURL("", parameters))
+	return accounts, err
+}
+
+// fetches single page of accounts from provided URL together with pagination links.
+func (c *HTTPClient) listPage(ctx context.Context, pageURL *url.URL) ([]Account, *links, error) {
+	request, err := c.newRequest(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	responseJSON := new(restTransportList)
 	err = c.doRequest(request, http.StatusOK, responseJSON)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	accounts := make([]Account, 0)
 	for _, accountJSON := range responseJSON.Data {
 		accounts = append(accounts, *accountFrom(accountJSON))
 	}
-	return accounts, nil
+	return accounts, &responseJSON.Links, nil
+}
+
+// Patch handles execution of patch command against accounts API.
+// Accepts context and account object - usually created with CastBuilderFrom from fetched account.
+// Account version must match the version stored by API, otherwise ErrVersionMismatch is returned.
+// Returns updated account object which will be received from API server after successful operation.
+///////
+// Context adds additional request configuration flexibility for SDK user.
+//////
+func (c *HTTPClient) Patch(ctx context.Context, account *Account) (*Account, error) {
+	if err := c.validateClient(); err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("cannot patch account without initialized account object. Use account builder")
+	}
+	if _, err := uuid.Parse(account.ID()); err != nil {
+		return nil, errors.Wrap(err, "provided account ID must be a valid UUID")
+	}
+
+	request, err := c.newRequest(ctx, http.MethodPatch, c.clientAPIRequestURL(account.ID(), nil), c.createTransportData(account))
+	if err != nil {
+		return nil, err
+	}
+
+	responseJSON := new(restTransport)
+	err = c.doRequest(request, http.StatusOK, responseJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return accountFrom(responseJSON.Data), nil
+}
+
+// Update fetches account, applies provided modification to its builder, validates and patches it.
+// If account was modified by someone else in between (version conflict) - whole cycle is repeated
+// with freshly fetched account, up to 5 attempts.
+// Returns updated account object, modification, validation or API error, or the last version conflict error when attempts run out.
+///////
+// Optimistic concurrency - modify function can be called several times, so it must not have side effects.
+//////
+func (c *HTTPClient) Update(ctx context.Context, accountID string, modify func(*Builder) error) (*Account, error) {
+	if modify == nil {
+		return nil, errors.New("cannot update account without modify function")
+	}
+	var lastErr error
+	for attempt := 0; attempt < updateAttempts; attempt++ {
+		if ctx != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		fetched, err := c.Fetch(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		builder := CastBuilderFrom(fetched)
+		if err := modify(builder); err != nil {
+			return nil, err
+		}
+		modified, err := builder.Validate()
+		if err != nil {
+			return nil, err
+		}
+		updated, err := c.Patch(ctx, modified)
+		if err == nil {
+			return updated, nil
+		}
+		// other conflicts, e.g. duplicate ID, would not be resolved by fetching the account again
+		if !errors.Is(err, ErrVersionMismatch) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
 }
 
 // Delete handles execution of delete command against accounts API.
@@ -190,7 +307,7 @@ func (c *HTTPClient) IsHealthy(ctx context.Context) bool {
 
 // checks if API client was properly initialized, so that API commands would fail fast if something is missing.
 func (c *HTTPClient) validateClient() error {
-	if c.httpClient == nil || c.apiHost == nil || c.apiEndpoint == nil {
+	if c.httpClient == nil || c.apiHost == nil || c.apiEndpoint == nil || c.healthPath == nil {
 		return errors.New("accounts api client was not initialised. Use constructor method")
 	}
 	return nil
@@ -209,6 +326,15 @@ func (c *HTTPClient) newRequest(ctx context.Context, method string, url *url.URL
 	if ctx != nil {
 		req = req.WithContext(ctx)
 	}
+	for key, values := range c.defaultHeaders {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if key := idempotencyKeyFrom(ctx); key != "" {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/vnd.api+json")
 	}
@@ -237,8 +363,7 @@ func (c *HTTPClient) clientAPIRequestURL(addToPath string, parameters map[string
 }
 
 func (c *HTTPClient) healthEndpoint() *url.URL {
-	healthEndpoint, _ := url.Parse("/v1/health")
-	return c.apiHost.ResolveReference(healthEndpoint)
+	return c.apiHost.ResolveReference(c.healthPath)
 }
 
 func (c *HTTPClient) addParameters(url *url.URL, parameters map[string]string) {
@@ -260,22 +385,25 @@ func (c *HTTPClient) createTransportData(account *Account) *restTransport {
 			OrganizationID: account.organizationID,
 			Version:        account.versionIndex,
 			Attributes:     *account.attributes(),
+			Relationships:  account.extra.Relationships,
+			Meta:           account.extra.Meta,
 		},
 	}
 }
 
 // handles SDK's http transport.
 // accepts expected http response code, so it can be used through different commands to choose if
-// received response is a happy day scenario - if not returns error together with status code and API response message.
+// received response is a happy day scenario - if not returns APIError with status code and API response message.
+// Transient failures are retried according to client's retry policy before the result is evaluated.
 func (c *HTTPClient) doRequest(request *http.Request, expectedResponseCode int, responseData interface{}) error {
-	response, err := c.httpClient.Do(request)
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != expectedResponseCode {
-		return errors.Wrap(c.errorFromStream(response.Body), response.Status)
+		return apiErrorFrom(response)
 	}
 	if responseData != nil {
 		return json.NewDecoder(response.Body).Decode(responseData)
@@ -283,14 +411,6 @@ func (c *HTTPClient) doRequest(request *http.Request, expectedResponseCode int,
 	return nil
 }
 
-func (c *HTTPClient) errorFromStream(stream io.ReadCloser) error {
-	bodyBytes, err := ioutil.ReadAll(stream)
-	if err != nil {
-		return errors.Wrap(err, "failed to read response stream.")
-	}
-	return errors.New(string(bodyBytes))
-}
-
 func (c *HTTPClient) pagingParameters(paging *PaginationSettings) map[string]string {
 	parameters := make(map[string]string)
 	if paging.Enabled {
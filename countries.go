@@ -1,8 +1,19 @@
 package account
 
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/currency"
+	"gopkg.in/go-playground/validator.v9"
+)
+
 // Country type represents supported countries list by the API.
 // It ensures correct country codes are used.
-// Has Bank ID Codes linked to the country.
+// Has validation rules linked to the country, see CountryRules.
 /////
 // Have chosen to have this custom implementation
 // as were there no package which would fulfill ISO 3166.
@@ -13,21 +24,21 @@ type Country string
 // List of countries supported by Form3
 const (
 	UnitedKingdom Country = "GB"
-	Australia             = "AU"
-	Belgium               = "BE"
-	Canada                = "CA"
-	France                = "FR"
-	Germany               = "DE"
-	Greece                = "GR"
-	HongKong              = "HK"
-	Italy                 = "IT"
-	Luxembourg            = "LU"
-	Netherlands           = "NL"
-	Poland                = "PL"
-	Portugal              = "PT"
-	Spain                 = "ES"
-	Switzerland           = "CH"
-	UnitedStates          = "US"
+	Australia     Country = "AU"
+	Belgium       Country = "BE"
+	Canada        Country = "CA"
+	France        Country = "FR"
+	Germany       Country = "DE"
+	Greece        Country = "GR"
+	HongKong      Country = "HK"
+	Italy         Country = "IT"
+	Luxembourg    Country = "LU"
+	Netherlands   Country = "NL"
+	Poland        Country = "PL"
+	Portugal      Country = "PT"
+	Spain         Country = "ES"
+	Switzerland   Country = "CH"
+	UnitedStates  Country = "US"
 )
 
 // Code returns string representation of ISO 3166 country code.
@@ -35,42 +46,233 @@ func (country Country) Code() string {
 	return string(country)
 }
 
+// ParseCountry returns supported country of ISO 3166 alpha-2 code, e.g. 'GB' or 'gb'.
+func ParseCountry(code string) (Country, error) {
+	country := Country(strings.ToUpper(strings.TrimSpace(code)))
+	if !country.IsSupported() {
+		return "", errors.Errorf("country %s is not supported", code)
+	}
+	return country, nil
+}
+
+// IsSupported tells if country has registered rules, see RegisterCountry.
+func (country Country) IsSupported() bool {
+	_, ok := country.Rules()
+	return ok
+}
+
+// SupportedCountries returns every registered country ordered by code, e.g. to list them in a form.
+func SupportedCountries() []Country {
+	countryRegistryMu.RLock()
+	defer countryRegistryMu.RUnlock()
+	countries := make([]Country, 0, len(countryRegistry))
+	for country := range countryRegistry {
+		countries = append(countries, country)
+	}
+	sort.Slice(countries, func(i, j int) bool {
+		return countries[i] < countries[j]
+	})
+	return countries
+}
+
 // BankIDCode returns associated Bank ID Code for given country
 func (country Country) BankIDCode() string {
-	var code string
-	switch country {
-	case UnitedKingdom:
-		code = "GBDSC"
-	case Australia:
-		code = "AUBSB"
-	case Belgium:
-		code = "BE"
-	case Canada:
-		code = "CACPA"
-	case France:
-		code = "FR"
-	case Germany:
-		code = "DEBLZ"
-	case Greece:
-		code = "GRBIC"
-	case HongKong:
-		code = "HKNCC"
-	case Italy:
-		code = "ITNCC"
-	case Luxembourg:
-		code = "LULUX"
-	case Netherlands:
-		code = ""
-	case Poland:
-		code = "PLKNR"
-	case Portugal:
-		code = "PTNCC"
-	case Spain:
-		code = "ESNCC"
-	case Switzerland:
-		code = "CHBCC"
-	case UnitedStates:
-		code = "USABA"
+	rules, _ := country.Rules()
+	return rules.BankIDCode
+}
+
+// Rules returns validation rules of the country and whether the country is supported.
+func (country Country) Rules() (CountryRules, bool) {
+	countryRegistryMu.RLock()
+	defer countryRegistryMu.RUnlock()
+	rules, ok := countryRegistry[country]
+	return rules, ok
+}
+
+// CountryRules describe how accounts of a country are identified and validated by Builder.
+// Formats and requirements are validator tags, e.g. 'len=6', 'required', 'len=0' for attributes the country does not use.
+// Empty tag accepts any value.
+type CountryRules struct {
+	// BankIDCode identifies the type of bank ID, e.g. 'GBDSC'. Empty if the country has none.
+	BankIDCode string
+	// BankID format, e.g. 'len=6' for UK sort code.
+	BankID string
+	// Bic requirement, e.g. 'len=8|len=11' where BIC is required.
+	Bic string
+	// Iban requirement, e.g. 'len=0' where IBAN is not used.
+	Iban string
+	// AccountNumber format. Account number is optional - API generates one when it is not provided,
+	// hence the format should start with 'omitempty'. Besides validator tags 'digits' accepts digits only.
+	AccountNumber string
+	// AccountNumberCheck verifies national check digits of account number, e.g. French RIB key.
+	// Called only when bank ID and account number match their formats. Optional.
+	AccountNumberCheck func(bankID, accountNumber string) error
+	// Currency is the base currency builder starts with.
+	Currency currency.Unit
+}
+
+///////
+// Everything country specific lives in one registry instead of a switch, struct tags and the constants,
+// so a country Form3 enables can be added in one place - by consumers as well, see RegisterCountry.
+// IBAN structure and BBAN derivation are kept apart, as they follow SWIFT registry and national standards.
+///////
+var (
+	countryRegistryMu sync.RWMutex
+	countryRegistry   = map[Country]CountryRules{
+		UnitedKingdom: {
+			BankIDCode:    "GBDSC",
+			BankID:        "len=6",
+			Bic:           "len=8|len=11",
+			AccountNumber: "omitempty,digits,len=8",
+			Currency:      currency.GBP,
+		},
+		Australia: {
+			BankIDCode:    "AUBSB",
+			Bic:           "len=8|len=11",
+			Iban:          "len=0",
+			AccountNumber: "omitempty,digits,min=6,max=10",
+			Currency:      currency.AUD,
+		},
+		Belgium: {
+			BankIDCode:         "BE",
+			BankID:             "len=3",
+			AccountNumber:      "omitempty,digits,len=7|len=9",
+			AccountNumberCheck: belgianCheckDigitsCheck,
+			Currency:           currency.EUR,
+		},
+		Canada: {
+			BankIDCode:    "CACPA",
+			Bic:           "len=8|len=11",
+			Iban:          "len=0",
+			AccountNumber: "omitempty,digits,min=7,max=12",
+			Currency:      currency.CAD,
+		},
+		France: {
+			BankIDCode: "FR",
+			BankID:     "len=10",
+			// French account number (numéro de compte) is 11 characters followed by 2 digit RIB key,
+			// as the 11 character account part of FR IBAN shows. 10 digits are bank and branch codes, i.e. bank ID.
+			AccountNumber:      "omitempty,alphanum,len=11|len=13",
+			AccountNumberCheck: ribKeyCheck,
+			Currency:           currency.EUR,
+		},
+		Germany: {
+			BankIDCode: "DEBLZ",
+			BankID:     "len=8",
+			// Form3 documents 7 digits, but German account numbers (Kontonummer) have up to 10 digits,
+			// as the 10 digit account part of DE IBAN shows. Shorter ones are padded with leading zeros.
+			AccountNumber: "omitempty,digits,min=7,max=10",
+			Currency:      currency.EUR,
+		},
+		Greece: {
+			BankIDCode:    "GRBIC",
+			BankID:        "len=7",
+			AccountNumber: "omitempty,alphanum,len=16",
+			Currency:      currency.EUR,
+		},
+		HongKong: {
+			BankIDCode:    "HKNCC",
+			Bic:           "len=8|len=11",
+			Iban:          "len=0",
+			AccountNumber: "omitempty,digits,min=9,max=12",
+			Currency:      currency.HKD,
+		},
+		Italy: {
+			BankIDCode:    "ITNCC",
+			BankID:        "len=10|len=11",
+			AccountNumber: "omitempty,alphanum,len=12",
+			Currency:      currency.EUR,
+		},
+		Luxembourg: {
+			BankIDCode:    "LULUX",
+			BankID:        "len=3",
+			AccountNumber: "omitempty,alphanum,len=13",
+			Currency:      currency.EUR,
+		},
+		Netherlands: {
+			BankID:        "len=0",
+			Bic:           "len=8|len=11",
+			AccountNumber: "omitempty,digits,max=10",
+			Currency:      currency.EUR,
+		},
+		Poland: {
+			BankIDCode:    "PLKNR",
+			BankID:        "len=8",
+			AccountNumber: "omitempty,digits,len=16",
+			Currency:      currency.PLN,
+		},
+		Portugal: {
+			BankIDCode:         "PTNCC",
+			BankID:             "len=8",
+			AccountNumber:      "omitempty,digits,len=11|len=13",
+			AccountNumberCheck: nibCheck,
+			Currency:           currency.EUR,
+		},
+		Spain: {
+			BankIDCode:         "ESNCC",
+			BankID:             "len=8",
+			AccountNumber:      "omitempty,digits,len=10|len=12",
+			AccountNumberCheck: cccCheck,
+			Currency:           currency.EUR,
+		},
+		Switzerland: {
+			BankIDCode:    "CHBCC",
+			BankID:        "len=5",
+			AccountNumber: "omitempty,alphanum,max=12",
+			Currency:      currency.CHF,
+		},
+		UnitedStates: {
+			BankIDCode:    "USABA",
+			BankID:        "len=9," + abaTag,
+			Bic:           "len=8|len=11",
+			Iban:          "len=0",
+			AccountNumber: "omitempty,digits,min=6,max=17",
+			Currency:      currency.USD,
+		},
 	}
-	return code
+)
+
+var countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// RegisterCountry adds or replaces validation rules of a country,
+// e.g. when Form3 enables a country before this library supports it.
+// Rules of supported countries can be replaced too, to follow API changes.
+func RegisterCountry(country Country, rules CountryRules) error {
+	if !countryCode.MatchString(country.Code()) {
+		return errors.Errorf("country %s must be ISO 3166 alpha-2 code", country.Code())
+	}
+	validate := newValidator()
+	for name, tag := range map[string]string{
+		"bank ID":        rules.BankID,
+		"BIC":            rules.Bic,
+		"IBAN":           rules.Iban,
+		"account number": rules.AccountNumber,
+	} {
+		if err := checkTag(validate, tag); err != nil {
+			return errors.Wrapf(err, "invalid %s rule of %s", name, country.Code())
+		}
+	}
+	countryRegistryMu.Lock()
+	defer countryRegistryMu.Unlock()
+	countryRegistry[country] = rules
+	return nil
+}
+
+// UnregisterCountry removes validation rules of a country, e.g. when Form3 stops supporting it.
+// Builder of unregistered country fails validation on 'country_supported' rule. Unknown countries are ignored.
+func UnregisterCountry(country Country) {
+	countryRegistryMu.Lock()
+	defer countryRegistryMu.Unlock()
+	delete(countryRegistry, country)
+}
+
+// validator panics on tags it cannot parse, hence the tag is tried out before it is registered.
+func checkTag(validate *validator.Validate, tag string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("%v", r)
+		}
+	}()
+	_ = validate.Var("", tag)
+	return nil
 }
@@ -0,0 +1,213 @@
+package account
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	// Change describes single account attribute which differs between two accounts.
+	Change struct {
+		// Attribute is JSON name of the attribute as used by accounts API, e.g. 'bank_id'.
+		Attribute string
+		// Old value of the attribute, as returned by its Account getter. Unknown attributes are json.RawMessage.
+		Old interface{}
+		// New value of the attribute.
+		New interface{}
+	}
+
+	// Changes lists every attribute which differs between two accounts, see Diff.
+	Changes []Change
+)
+
+// attributes compared as sets, regardless of order and repeated values.
+var unorderedAttributes = map[string]bool{
+	"alternative_bank_account_names": true,
+	"alternative_names":              true,
+}
+
+// JSON names of attributes holding personal data, masked in Report the way Account.String masks them.
+var personalAttributes = map[string]bool{
+	"account_number":                 true,
+	"iban":                           true,
+	"customer_id":                    true,
+	"first_name":                     true,
+	"bank_account_name":              true,
+	"alternative_bank_account_names": true,
+	"secondary_identification":       true,
+	"name":                           true,
+	"alternative_names":              true,
+	"user_defined_data":              true,
+	"private_identification":         true,
+	"organisation_identification":    true,
+}
+
+///////
+// Attributes are compared the way they are sent to accounts API, so every new attribute
+// of the transport model is covered by Diff without changes here.
+// Empty and missing values are the same thing to API, hence nil and empty lists are equal.
+///////
+
+// Diff lists attributes which differ between accounts a and b, in order of accounts resource.
+// Attributes not modelled by the SDK, see Account.Extra, follow in alphabetical order.
+// Account ID, version and timestamps are not attributes and are not compared. Nil account is treated as empty one.
+func Diff(a, b *Account) Changes {
+	if a == nil {
+		a = &Account{}
+	}
+	if b == nil {
+		b = &Account{}
+	}
+	oldAttributes := reflect.ValueOf(*a.attributes())
+	newAttributes := reflect.ValueOf(*b.attributes())
+	attributesType := oldAttributes.Type()
+
+	changes := make(Changes, 0)
+	for i := 0; i < attributesType.NumField(); i++ {
+		name := jsonName(attributesType.Field(i))
+		if name == "" {
+			continue
+		}
+		oldValue, newValue := oldAttributes.Field(i), newAttributes.Field(i)
+		if !equalAttributes(name, oldValue, newValue) {
+			changes = append(changes, Change{Attribute: name, Old: oldValue.Interface(), New: newValue.Interface()})
+		}
+	}
+	return append(changes, diffExtra(a.extra.Attributes, b.extra.Attributes)...)
+}
+
+func equalAttributes(name string, a, b reflect.Value) bool {
+	if isEmpty(a) || isEmpty(b) {
+		return isEmpty(a) && isEmpty(b)
+	}
+	if unorderedAttributes[name] {
+		return reflect.DeepEqual(sortedSet(a.Interface().([]string)), sortedSet(b.Interface().([]string)))
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+func isEmpty(value reflect.Value) bool {
+	if value.Kind() == reflect.Slice {
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
+
+// returns distinct values in order, so lists holding the same values are equal regardless of order and repetition.
+func sortedSet(values []string) []string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	set := sorted[:0]
+	for i, value := range sorted {
+		if i == 0 || value != sorted[i-1] {
+			set = append(set, value)
+		}
+	}
+	return set
+}
+
+func diffExtra(a, b map[string]json.RawMessage) Changes {
+	names := make([]string, 0, len(a)+len(b))
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, found := a[name]; !found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := make(Changes, 0)
+	for _, name := range names {
+		if !bytes.Equal(compactJSON(a[name]), compactJSON(b[name])) {
+			changes = append(changes, Change{Attribute: name, Old: a[name], New: b[name]})
+		}
+	}
+	return changes
+}
+
+func compactJSON(raw json.RawMessage) []byte {
+	buffer := new(bytes.Buffer)
+	if err := json.Compact(buffer, raw); err != nil {
+		return raw
+	}
+	return buffer.Bytes()
+}
+
+// IsEmpty tells if accounts do not differ.
+func (c Changes) IsEmpty() bool {
+	return len(c) == 0
+}
+
+// PatchAttributes renders changes as minimal attributes object of PATCH request, holding new values only,
+// e.g. {"bank_id":"400516"}. Removed lists, identifications and unknown attributes are sent as null,
+// other removed attributes as their empty values.
+func (c Changes) PatchAttributes() (json.RawMessage, error) {
+	attributes := make(map[string]interface{}, len(c))
+	for _, change := range c {
+		value := change.New
+		if list := reflect.ValueOf(value); list.Kind() == reflect.Slice && list.Len() == 0 {
+			value = nil
+		}
+		attributes[change.Attribute] = value
+	}
+	return json.Marshal(attributes)
+}
+
+// Report renders changes as human readable text, one attribute per line, e.g. 'bank_id: "400515" -> "400516"'.
+// Personal data is masked like in Account.String, e.g. 'account_number: "31****19" -> "31****27"',
+// so reports can be logged. Change keeps the values as is.
+func (c Changes) Report() string {
+	if c.IsEmpty() {
+		return "no changes"
+	}
+	lines := make([]string, len(c))
+	for i, change := range c {
+		lines[i] = fmt.Sprintf("%s: %s -> %s", change.Attribute, reportValue(change.Attribute, change.Old), reportValue(change.Attribute, change.New))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c Changes) String() string {
+	return c.Report()
+}
+
+func reportValue(attribute string, value interface{}) string {
+	if flag, isBool := value.(bool); isBool {
+		return fmt.Sprint(flag)
+	}
+	if value == nil || isEmpty(reflect.ValueOf(value)) {
+		return "none"
+	}
+	if personalAttributes[attribute] {
+		return reportPersonalValue(attribute, value)
+	}
+	switch typed := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", typed)
+	case json.RawMessage:
+		return string(compactJSON(typed))
+	}
+	return fmt.Sprintf("%+v", value)
+}
+
+// masks personal data the way Account.String does, identifications are not printed at all.
+func reportPersonalValue(attribute string, value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		if attribute == "iban" {
+			return fmt.Sprintf("%q", maskIBAN(typed))
+		}
+		return fmt.Sprintf("%q", maskPII(typed))
+	case []string:
+		return fmt.Sprintf("%q", maskPIIList(typed))
+	case []UserDefinedData:
+		return fmt.Sprintf("%+v", printedUserDefinedData(typed, maskPII))
+	}
+	return redacted
+}
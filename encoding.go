@@ -0,0 +1,157 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+///////
+// Account keeps its fields unexported, so it can only be created by Builder.
+// Encoding methods have value receivers, so accounts held by value, e.g. in maps or struct fields, are encoded too.
+// Serialisation keeps that promise: decoded accounts are cast to Builder and validated,
+// hence account read from cache, queue or file obeys the same rules as the one built in code.
+///////
+
+// keys of accounts resource which are kept next to attributes in JSON:API envelope.
+var resourceKeys = []string{"id", "organisation_id", "version", "created_on", "modified_on", "relationships", "meta"}
+
+// MarshalJSON encodes account as JSON:API envelope used by accounts API, e.g. {"data":{"type":"accounts",...}}.
+// Use MarshalFlatJSON for attributes only view.
+func (acc Account) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Data transportData `json:"data"`
+	}{acc.transportData()})
+}
+
+// MarshalFlatJSON encodes account as a single JSON object holding ID, organisation ID, version,
+// creation and modification times together with all attributes, e.g. {"id":"...","country":"GB",...}.
+func (acc Account) MarshalFlatJSON() ([]byte, error) {
+	data, err := json.Marshal(acc.transportData())
+	if err != nil {
+		return nil, err
+	}
+	resource := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+	flat := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(resource["attributes"], &flat); err != nil {
+		return nil, err
+	}
+	for _, key := range resourceKeys {
+		if value, found := resource[key]; found {
+			flat[key] = value
+		}
+	}
+	return json.Marshal(flat)
+}
+
+// UnmarshalJSON decodes account from either JSON:API envelope or flat JSON, see MarshalJSON and MarshalFlatJSON.
+// Decoded account is validated with Builder rules of its country, ValidationErrors are returned if it is invalid.
+func (acc *Account) UnmarshalJSON(data []byte) error {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	resource, isEnvelope := fields["data"]
+	if !isEnvelope {
+		var err error
+		if resource, err = resourceFromFlat(fields); err != nil {
+			return err
+		}
+	}
+	var decoded transportData
+	if err := json.Unmarshal(resource, &decoded); err != nil {
+		return err
+	}
+	received := accountFrom(decoded)
+	validated, err := CastBuilderFrom(received).Validate()
+	if err != nil {
+		return errors.Wrap(err, "decoded account is invalid")
+	}
+	*acc = *validated.withServerAttributes(received.versionIndex, received.createdOn, received.modifiedOn)
+	return nil
+}
+
+// MarshalYAML encodes account the same way as MarshalJSON. Implements yaml.Marshaler of gopkg.in/yaml.v2 and v3.
+func (acc Account) MarshalYAML() (interface{}, error) {
+	data, err := acc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	err = json.Unmarshal(data, &document)
+	return document, err
+}
+
+// UnmarshalYAML decodes account from YAML document of either JSON:API envelope or flat structure
+// and validates it like UnmarshalJSON. Implements yaml.Unmarshaler of gopkg.in/yaml.v2.
+func (acc *Account) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var document interface{}
+	if err := unmarshal(&document); err != nil {
+		return err
+	}
+	data, err := json.Marshal(jsonCompatible(document))
+	if err != nil {
+		return err
+	}
+	return acc.UnmarshalJSON(data)
+}
+
+// used for generating JSON:API resource of the account
+func (acc *Account) transportData() transportData {
+	return transportData{
+		Type:           "accounts",
+		ID:             acc.id,
+		OrganizationID: acc.organizationID,
+		Version:        acc.versionIndex,
+		CreatedOn:      acc.createdOn,
+		ModifiedOn:     acc.modifiedOn,
+		Attributes:     *acc.attributes(),
+		Relationships:  acc.extra.Relationships,
+		Meta:           acc.extra.Meta,
+	}
+}
+
+// moves resource keys of flat JSON out of attributes, so it can be decoded as JSON:API resource.
+func resourceFromFlat(flat map[string]json.RawMessage) (json.RawMessage, error) {
+	resource := make(map[string]json.RawMessage)
+	for _, key := range resourceKeys {
+		if value, found := flat[key]; found {
+			resource[key] = value
+			delete(flat, key)
+		}
+	}
+	delete(flat, "type")
+	attributes, err := json.Marshal(flat)
+	if err != nil {
+		return nil, err
+	}
+	resource["attributes"] = attributes
+	return json.Marshal(resource)
+}
+
+// YAML decoders produce maps with interface{} keys, which cannot be encoded as JSON.
+func jsonCompatible(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			converted[fmt.Sprint(key)] = jsonCompatible(item)
+		}
+		return converted
+	case map[string]interface{}:
+		for key, item := range typed {
+			typed[key] = jsonCompatible(item)
+		}
+		return typed
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = jsonCompatible(item)
+		}
+		return typed
+	}
+	return value
+}
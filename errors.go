@@ -0,0 +1,120 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Sentinel errors which APIError can be matched against with errors.Is.
+var (
+	// ErrNotFound - requested account does not exist.
+	ErrNotFound = errors.New("account not found")
+	// ErrConflict - request conflicts with current state of the account, e.g. duplicate ID or stale version.
+	ErrConflict = errors.New("account conflict")
+	// ErrVersionMismatch - provided account version is not the current one. Also matches ErrConflict.
+	ErrVersionMismatch = errors.New("account version mismatch")
+	// ErrRateLimited - API rejected request due to rate limiting.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrValidation - API rejected request payload or parameters.
+	ErrValidation = errors.New("invalid request")
+)
+
+// error_code values which identify conflicts regardless of how error_message is worded.
+// ErrVersionMismatch falls back to error_message only for responses without one of these codes.
+const (
+	// ErrorCodeVersionMismatch - provided account version is not the current one.
+	ErrorCodeVersionMismatch = "version_mismatch"
+	// ErrorCodeDuplicateID - account with the same ID already exists.
+	ErrorCodeDuplicateID = "duplicate_id"
+)
+
+// APIError is returned by HTTPClient commands when API responds with unexpected status code.
+///////
+// Form3 API describes failures with error_code and error_message fields in JSON body,
+// those are parsed, while raw body is kept for responses which do not follow the format.
+///////
+type APIError struct {
+	// StatusCode of the API response
+	StatusCode int
+	// ErrorCode as returned by the API in error_code field
+	ErrorCode string
+	// ErrorMessage as returned by the API in error_message field
+	ErrorMessage string
+	// Method of the failed request
+	Method string
+	// URL of the failed request
+	URL string
+	// Body of the API response
+	Body []byte
+}
+
+type errorTransport struct {
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+func (e *APIError) Error() string {
+	message := e.ErrorMessage
+	if message == "" {
+		message = strings.TrimSpace(string(e.Body))
+	}
+	status := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if message == "" {
+		return fmt.Sprintf("%s %s: %s", e.Method, e.URL, status)
+	}
+	return fmt.Sprintf("%s %s: %s: %s", e.Method, e.URL, status, message)
+}
+
+// Is enables matching APIError against sentinel errors with errors.Is.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrVersionMismatch:
+		return e.StatusCode == http.StatusConflict && e.isVersionMismatch()
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest
+	}
+	return false
+}
+
+// error_code tells conflicts apart when the API sends a known one, error_message wording is only a fallback.
+func (e *APIError) isVersionMismatch() bool {
+	switch e.ErrorCode {
+	case ErrorCodeVersionMismatch:
+		return true
+	case ErrorCodeDuplicateID:
+		return false
+	}
+	return strings.Contains(strings.ToLower(e.ErrorMessage), "version")
+}
+
+// builds APIError from unexpected API response.
+func apiErrorFrom(response *http.Response) *APIError {
+	// status code is what matters the most, so partially read body is still reported
+	body, _ := ioutil.ReadAll(io.LimitReader(response.Body, 1<<20))
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		Body:       body,
+	}
+	if response.Request != nil {
+		apiErr.Method = response.Request.Method
+		apiErr.URL = response.Request.URL.String()
+	}
+	transport := errorTransport{}
+	if json.Unmarshal(body, &transport) == nil {
+		apiErr.ErrorCode = transport.ErrorCode
+		apiErr.ErrorMessage = transport.ErrorMessage
+	}
+	return apiErr
+}
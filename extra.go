@@ -0,0 +1,111 @@
+package account
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Extra holds account JSON which the SDK does not model yet, as received from accounts API.
+// It is kept on fetched accounts, carried through CastBuilderFrom and sent back on writes,
+// so attributes added by API are not lost when account is updated with an older SDK.
+type Extra struct {
+	// Attributes not known to the SDK, keyed by their JSON name.
+	Attributes map[string]json.RawMessage
+	// Relationships of the account resource.
+	Relationships json.RawMessage
+	// Meta of the account resource.
+	Meta json.RawMessage
+}
+
+// IsEmpty tells if there is no unknown JSON.
+func (e Extra) IsEmpty() bool {
+	return len(e.Attributes) == 0 && len(e.Relationships) == 0 && len(e.Meta) == 0
+}
+
+// returns deep copy, so accounts and builders do not share raw JSON.
+func (e Extra) clone() Extra {
+	cloned := Extra{
+		Relationships: cloneRaw(e.Relationships),
+		Meta:          cloneRaw(e.Meta),
+	}
+	if e.Attributes != nil {
+		cloned.Attributes = make(map[string]json.RawMessage, len(e.Attributes))
+		for key, value := range e.Attributes {
+			cloned.Attributes[key] = cloneRaw(value)
+		}
+	}
+	return cloned
+}
+
+func cloneRaw(raw json.RawMessage) json.RawMessage {
+	if raw == nil {
+		return nil
+	}
+	return append(json.RawMessage(nil), raw...)
+}
+
+// JSON names of attributes modelled by the SDK.
+var knownAttributes = func() map[string]bool {
+	known := make(map[string]bool)
+	attributesType := reflect.TypeOf(accountAttributes{})
+	for i := 0; i < attributesType.NumField(); i++ {
+		if name := jsonName(attributesType.Field(i)); name != "" {
+			known[name] = true
+		}
+	}
+	return known
+}()
+
+// returns JSON name of struct field, empty for fields without json tag.
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+///////
+// Attributes are decoded twice: once into the struct and once into a map,
+// so every key which has no struct field is kept as is.
+// Known attributes always win on writes, unknown ones can never override them.
+///////
+
+// UnmarshalJSON decodes known attributes and keeps the unknown ones.
+func (a *accountAttributes) UnmarshalJSON(data []byte) error {
+	type plain accountAttributes
+	if err := json.Unmarshal(data, (*plain)(a)); err != nil {
+		return err
+	}
+	all := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	a.extra = nil
+	for key, value := range all {
+		if knownAttributes[key] {
+			continue
+		}
+		if a.extra == nil {
+			a.extra = make(map[string]json.RawMessage)
+		}
+		a.extra[key] = value
+	}
+	return nil
+}
+
+// MarshalJSON encodes known attributes together with the unknown ones.
+func (a accountAttributes) MarshalJSON() ([]byte, error) {
+	type plain accountAttributes
+	data, err := json.Marshal(plain(a))
+	if err != nil || len(a.extra) == 0 {
+		return data, err
+	}
+	all := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for key, value := range a.extra {
+		if _, found := all[key]; !found && !knownAttributes[key] {
+			all[key] = value
+		}
+	}
+	return json.Marshal(all)
+}
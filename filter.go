@@ -0,0 +1,122 @@
+package account
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Filter narrows down listed accounts to the ones matching all set attributes.
+// Empty attributes are not used for filtering.
+///////
+// Filter values are validated with the same country rules account Builder uses,
+// so a filter which could never match a valid account fails fast instead of returning an empty list.
+///////
+type Filter struct {
+	Country       Country
+	BankID        string
+	BankIDCode    string
+	AccountNumber string
+	Iban          string
+	CustomerID    string
+}
+
+// Normalise returns copy of filter with IBAN in electronic format, as list commands send it,
+// e.g. 'GB82WEST12345698765432' for 'gb82 west 1234 5698 7654 32'.
+func (f Filter) Normalise() Filter {
+	f.Iban = normaliseIban(f.Iban)
+	return f
+}
+
+// Validate checks filter values the way list commands do before a request is sent, see Filter.
+// IBAN in print format has to be normalised first, see Normalise.
+func (f Filter) Validate() error {
+	if f.Country == "" {
+		if err := validateFilterBankIDCode(f.BankIDCode); err != nil {
+			return err
+		}
+		return f.validateIban()
+	}
+	rules, ok := f.Country.Rules()
+	if !ok {
+		return errors.Errorf("country %s is not supported", f.Country.Code())
+	}
+	if f.BankIDCode != "" && f.BankIDCode != rules.BankIDCode {
+		return errors.Errorf("bank ID code %s is not used in %s", f.BankIDCode, f.Country.Code())
+	}
+	validate := newValidator()
+	if f.BankID != "" {
+		if err := validateVar(validate, "bank ID", f.BankID, rules.BankID); err != nil {
+			return err
+		}
+	}
+	if f.AccountNumber != "" {
+		if err := validateVar(validate, "account number", f.AccountNumber, rules.AccountNumber); err != nil {
+			return err
+		}
+	}
+	if f.Iban != "" {
+		if err := validateVar(validate, "IBAN", f.Iban, rules.Iban); err != nil {
+			return err
+		}
+	}
+	return f.validateIban()
+}
+
+// IBAN carries its own country code and check digits, so it is validated even if filter country is not set.
+// Otherwise IBAN country has to match filter country.
+func (f Filter) validateIban() error {
+	if f.Iban == "" {
+		return nil
+	}
+	country := f.Country
+	if country == "" && len(f.Iban) >= 2 {
+		country = Country(f.Iban[:2])
+	}
+	_, err := validateIban(country, f.Iban)
+	return err
+}
+
+// bank ID code without filter country has to be used by at least one supported country.
+func validateFilterBankIDCode(bankIDCode string) error {
+	if bankIDCode == "" {
+		return nil
+	}
+	for _, country := range SupportedCountries() {
+		if country.BankIDCode() == bankIDCode {
+			return nil
+		}
+	}
+	return errors.Errorf("bank ID code %s is not used in any supported country", bankIDCode)
+}
+
+func (f *Filter) parameters() map[string]string {
+	parameters := make(map[string]string)
+	values := map[string]string{
+		"filter[country]":        f.Country.Code(),
+		"filter[bank_id]":        f.BankID,
+		"filter[bank_id_code]":   f.BankIDCode,
+		"filter[account_number]": f.AccountNumber,
+		"filter[iban]":           f.Iban,
+		"filter[customer_id]":    f.CustomerID,
+	}
+	for key, value := range values {
+		if value != "" {
+			parameters[key] = value
+		}
+	}
+	return parameters
+}
+
+// picks a single filter out of optional variadic parameter of list commands.
+func singleFilter(filters []Filter) (*Filter, error) {
+	switch len(filters) {
+	case 0:
+		return nil, nil
+	case 1:
+		filter := filters[0].Normalise()
+		if err := filter.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid filter")
+		}
+		return &filter, nil
+	}
+	return nil, errors.New("at most one filter can be provided")
+}
@@ -0,0 +1,125 @@
+package account
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ibanFormat describes IBAN structure of a single country as published in SWIFT IBAN registry.
+type ibanFormat struct {
+	length int
+	bban   *regexp.Regexp
+}
+
+///////
+// Only countries supported by the API are listed. Countries without IBAN (AU, CA, HK, US)
+// are not here - their builders reject any IBAN with country rules, see CountryRules.
+///////
+var ibanFormats = map[Country]ibanFormat{
+	UnitedKingdom: {length: 22, bban: regexp.MustCompile(`^[A-Z]{4}[0-9]{14}$`)},
+	Belgium:       {length: 16, bban: regexp.MustCompile(`^[0-9]{12}$`)},
+	France:        {length: 27, bban: regexp.MustCompile(`^[0-9]{10}[A-Z0-9]{11}[0-9]{2}$`)},
+	Germany:       {length: 22, bban: regexp.MustCompile(`^[0-9]{18}$`)},
+	Greece:        {length: 27, bban: regexp.MustCompile(`^[0-9]{7}[A-Z0-9]{16}$`)},
+	Italy:         {length: 27, bban: regexp.MustCompile(`^[A-Z][0-9]{10}[A-Z0-9]{12}$`)},
+	Luxembourg:    {length: 20, bban: regexp.MustCompile(`^[0-9]{3}[A-Z0-9]{13}$`)},
+	Netherlands:   {length: 18, bban: regexp.MustCompile(`^[A-Z]{4}[0-9]{10}$`)},
+	Poland:        {length: 28, bban: regexp.MustCompile(`^[0-9]{24}$`)},
+	Portugal:      {length: 25, bban: regexp.MustCompile(`^[0-9]{21}$`)},
+	Spain:         {length: 24, bban: regexp.MustCompile(`^[0-9]{20}$`)},
+	Switzerland:   {length: 21, bban: regexp.MustCompile(`^[0-9]{5}[A-Z0-9]{12}$`)},
+}
+
+var ibanCharacters = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]+$`)
+
+// normaliseIban converts IBAN in print format, e.g. 'GB82 WEST 1234 5698 7654 32',
+// to electronic format, e.g. 'GB82WEST12345698765432'.
+func normaliseIban(iban string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(iban), ""))
+}
+
+// IBAN validation rules reported in ValidationError.
+const (
+	ibanFormatRule   = "iban_format"
+	ibanCountryRule  = "iban_country"
+	ibanLengthRule   = "iban_length"
+	ibanBbanRule     = "iban_bban"
+	ibanChecksumRule = "iban_checksum"
+	// IBAN cannot be derived from bank ID, account number and BIC
+	ibanDerivationRule = "iban_derivation"
+	// set IBAN differs from the derived one
+	ibanDerivedRule = "iban_derived"
+)
+
+// validateIban checks IBAN in electronic format against ISO 13616:
+// country prefix, per country length, BBAN structure and mod-97 check digits.
+// Returns violated rule together with the error.
+// Empty IBAN is valid, as API calculates it from other attributes if not supplied.
+func validateIban(country Country, iban string) (string, error) {
+	if iban == "" {
+		return "", nil
+	}
+	if !ibanCharacters.MatchString(iban) {
+		return ibanFormatRule, errors.Errorf("IBAN %s must consist of country code, 2 check digits and alphanumeric BBAN", iban)
+	}
+	if prefix := Country(iban[:2]); prefix != country {
+		return ibanCountryRule, errors.Errorf("IBAN %s country code %s does not match account country %s", iban, prefix, country.Code())
+	}
+	// structure of registered countries missing from the list is not known, only check digits are validated
+	if format, ok := ibanFormats[country]; ok {
+		if len(iban) != format.length {
+			return ibanLengthRule, errors.Errorf("IBAN %s must be %d characters long in %s", iban, format.length, country.Code())
+		}
+		if !format.bban.MatchString(iban[4:]) {
+			return ibanBbanRule, errors.Errorf("IBAN %s has invalid BBAN format for %s", iban, country.Code())
+		}
+	}
+	if ibanMod97(iban[4:]+iban[:4]) != 1 {
+		return ibanChecksumRule, errors.Errorf("IBAN %s has invalid check digits", iban)
+	}
+	return "", nil
+}
+
+// DeriveIban calculates IBAN in electronic format from the attributes account is identified with locally.
+// Depending on the country BBAN is built from bank ID, account number and first 4 characters of BIC (GB, NL)
+// followed by national check digits where the country requires them (BE, FR, IT, PT, ES).
+// Returns error if country does not use IBAN or attributes required by the country are missing or malformed.
+func DeriveIban(country Country, bankID, accountNumber, bic string) (string, error) {
+	buildBban, ok := bbanBuilders[country]
+	if !ok {
+		return "", errors.Errorf("IBAN is not used in %s", country.Code())
+	}
+	bban, err := buildBban(bbanParts{
+		bankID:        bankID,
+		accountNumber: strings.ToUpper(accountNumber),
+		bic:           strings.ToUpper(bic),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot derive IBAN in %s", country.Code())
+	}
+	checkDigits := twoDigits(98 - ibanMod97(bban+country.Code()+"00"))
+	iban := country.Code() + checkDigits + bban
+	if _, err := validateIban(country, iban); err != nil {
+		return "", errors.Wrapf(err, "cannot derive IBAN in %s", country.Code())
+	}
+	return iban, nil
+}
+
+// computes ISO 7064 mod 97-10 remainder of alphanumeric string, letters are replaced by numbers A=10 ... Z=35.
+// Remainder is calculated digit by digit, as the whole number does not fit into int64.
+func ibanMod97(s string) int {
+	remainder := 0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		default:
+			return -1
+		}
+	}
+	return remainder
+}
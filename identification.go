@@ -0,0 +1,51 @@
+package account
+
+type (
+	// UserDefinedData is a free-format key value pair stored with the account, e.g. reference of an external system.
+	UserDefinedData struct {
+		Key   string `json:"key" validate:"required,max=50"`
+		Value string `json:"value" validate:"max=1000"`
+	}
+
+	// PrivateIdentification identifies account holder who is a person. Used by Personal accounts.
+	PrivateIdentification struct {
+		// BirthDate in YYYY-MM-DD format.
+		BirthDate string `json:"birth_date,omitempty" validate:"omitempty,date"`
+		// BirthCountry ISO 3166 country code.
+		BirthCountry string `json:"birth_country,omitempty" validate:"omitempty,len=2"`
+		// Identification e.g. passport number.
+		Identification string   `json:"identification,omitempty" validate:"max=140"`
+		Address        []string `json:"address,omitempty" validate:"max=3,dive,max=140"`
+		City           string   `json:"city,omitempty" validate:"max=35"`
+		// Country of residence, ISO 3166 country code.
+		Country string `json:"country,omitempty" validate:"omitempty,len=2"`
+	}
+
+	// OrganisationIdentification identifies account holder which is an organisation. Used by Business accounts.
+	OrganisationIdentification struct {
+		// Identification e.g. company registration number.
+		Identification string `json:"identification,omitempty" validate:"max=140"`
+		// Actors are people acting on behalf of the organisation, e.g. directors.
+		Actors  []Actor  `json:"actors,omitempty" validate:"dive"`
+		Address []string `json:"address,omitempty" validate:"max=3,dive,max=140"`
+		City    string   `json:"city,omitempty" validate:"max=35"`
+		// Country of registration, ISO 3166 country code.
+		Country string `json:"country,omitempty" validate:"omitempty,len=2"`
+	}
+
+	// Actor is a person acting on behalf of an organisation.
+	Actor struct {
+		Name []string `json:"name,omitempty" validate:"max=4,dive,max=255"`
+		// BirthDate in YYYY-MM-DD format.
+		BirthDate string `json:"birth_date,omitempty" validate:"omitempty,date"`
+		// Residency ISO 3166 country code.
+		Residency string `json:"residency,omitempty" validate:"omitempty,len=2"`
+	}
+)
+
+// Account statuses.
+const (
+	StatusPending   = "pending"
+	StatusConfirmed = "confirmed"
+	StatusClosed    = "closed"
+)
@@ -0,0 +1,14 @@
+// Package stamp gives AccountsAPI implementations of this module, e.g. accountfake,
+// access to account attributes which are managed by accounts API server.
+// Package is internal, so SDK users cannot forge version or timestamps of accounts they build.
+package stamp
+
+import "time"
+
+// Account returns a copy of acc, which must be *account.Account, with version number
+// and creation and modification times replaced. It is set by package account when it is initialised.
+///////
+// Package account imports nothing from here but this hook, so no import cycle is created
+// and Account keeps all of its fields unexported.
+///////
+var Account func(acc interface{}, version int, createdOn, modifiedOn time.Time) interface{}
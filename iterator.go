@@ -0,0 +1,126 @@
+package account
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// AccountIterator walks through all accounts page by page, following pagination links returned by API.
+// Next page is requested only when accounts of the current one are exhausted.
+//
+// Usage:
+//
+//	it := client.ListAll(ctx, 100)
+//	for it.Next() {
+//		acc := it.Account()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type AccountIterator struct {
+	client  *HTTPClient
+	ctx     context.Context
+	filter  *Filter
+	next    *url.URL
+	page    []Account
+	index   int
+	current *Account
+	err     error
+}
+
+// ListAll creates an iterator over all available accounts, requesting them from API in pages of provided size.
+// Optionally accepts a single filter which narrows down listed accounts on server side.
+///////
+// Iterator follows links.next instead of counting page numbers,
+// so it keeps working even if API changes the way pages are addressed.
+///////
+func (c *HTTPClient) ListAll(ctx context.Context, pageSize int, filter ...Filter) *AccountIterator {
+	it := &AccountIterator{client: c, ctx: ctx}
+	if err := c.validateClient(); err != nil {
+		it.err = err
+		return it
+	}
+	if pageSize < 1 {
+		it.err = errors.New("page size must be a positive number")
+		return it
+	}
+	if it.filter, it.err = singleFilter(filter); it.err != nil {
+		return it
+	}
+	parameters := it.filterParameters()
+	parameters["page[number]"] = "0"
+	parameters["page[size]"] = strconv.Itoa(pageSize)
+	it.next = c.clientAPIRequestURL("", parameters)
+	return it
+}
+
+// Next advances iterator to the next account, fetching next page if needed.
+// Returns false when there are no more accounts or an error occurred - check Err.
+func (it *AccountIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.index >= len(it.page) {
+		if it.next == nil {
+			it.current = nil
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			it.current = nil
+			return false
+		}
+	}
+	it.current = &it.page[it.index]
+	it.index++
+	return true
+}
+
+// Account returns account iterator currently points to. Returns nil before Next is called or after iteration is over.
+func (it *AccountIterator) Account() *Account {
+	return it.current
+}
+
+// Err returns error which stopped the iteration, if any.
+func (it *AccountIterator) Err() error {
+	return it.err
+}
+
+func (it *AccountIterator) fetch() error {
+	if it.ctx != nil && it.ctx.Err() != nil {
+		return it.ctx.Err()
+	}
+	current := it.next
+	accounts, pageLinks, err := it.client.listPage(it.ctx, current)
+	if err != nil {
+		return err
+	}
+	it.page = accounts
+	it.index = 0
+	it.next = nil
+	if len(accounts) == 0 || pageLinks.Next == "" {
+		return nil
+	}
+	next, err := url.Parse(pageLinks.Next)
+	if err != nil {
+		return errors.Wrap(err, "API returned invalid next page link")
+	}
+	next = it.client.apiHost.ResolveReference(next)
+	// API does not necessarily keep filter in the links
+	it.client.addParameters(next, it.filterParameters())
+	// protects from endless loop if API keeps pointing to the same page
+	if next.String() != current.String() {
+		it.next = next
+	}
+	return nil
+}
+
+func (it *AccountIterator) filterParameters() map[string]string {
+	if it.filter == nil {
+		return make(map[string]string)
+	}
+	return it.filter.parameters()
+}
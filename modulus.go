@@ -0,0 +1,347 @@
+package account
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Modulus checking algorithms defined by Vocalink.
+const (
+	modulus10    = "MOD10"
+	modulus11    = "MOD11"
+	doubleAltern = "DBLAL"
+)
+
+// modulusRule is reported in ValidationError when GB account number fails modulus check.
+const modulusRule = "modulus"
+
+type (
+	// ModulusTable holds Vocalink modulus weight table and sorting code substitution table
+	// used to check UK sort code and account number pairs.
+	///////
+	// Vocalink publishes both tables as text files and updates them regularly,
+	// hence they are parsed at runtime instead of being turned into code.
+	///////
+	ModulusTable struct {
+		weights       []modulusWeight
+		substitutions map[string]string
+	}
+
+	// single row of weight table: sort code range, algorithm, 14 weights (u v w x y z a b c d e f g h) and exception.
+	modulusWeight struct {
+		from      string
+		to        string
+		method    string
+		weights   [14]int
+		exception int
+	}
+)
+
+//go:generate go run ./scripts/modulusgen
+
+///////
+// Published Vocalink tables are bundled as generated Go source, as Go 1.13 cannot embed files.
+// Vocalink updates them several times a year: refresh the bundled ones with go generate,
+// or swap newer ones in at runtime with SetModulusTable without waiting for an SDK release.
+///////
+var (
+	modulusTableMu sync.RWMutex
+	modulusTable   = DefaultModulusTable()
+)
+
+// DefaultModulusTable returns Vocalink tables bundled with the SDK, used until SetModulusTable is called.
+// Returns nil if the SDK is built without them, in which case GB accounts are not modulus checked.
+func DefaultModulusTable() *ModulusTable {
+	if strings.TrimSpace(vocalinkWeights) == "" {
+		return nil
+	}
+	// bundled tables are validated when they are generated
+	table, err := ParseModulusTable(strings.NewReader(vocalinkWeights), strings.NewReader(vocalinkSubstitutions))
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+// ParseModulusTable parses Vocalink weight table (valacdos.txt) and sorting code substitution table (scsubtab.txt).
+// Substitution table is used by exception 5 only and can be nil.
+// Blank lines and lines starting with '#' are ignored.
+func ParseModulusTable(weights, substitutions io.Reader) (*ModulusTable, error) {
+	table := &ModulusTable{substitutions: make(map[string]string)}
+	if err := scanTable(weights, func(fields []string) error {
+		weight, err := parseModulusWeight(fields)
+		if err != nil {
+			return err
+		}
+		table.weights = append(table.weights, weight)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "invalid modulus weight table")
+	}
+	if substitutions == nil {
+		return table, nil
+	}
+	if err := scanTable(substitutions, func(fields []string) error {
+		if len(fields) != 2 || !isSortCode(fields[0]) || !isSortCode(fields[1]) {
+			return errors.Errorf("expected original and substitute sort codes, got %q", strings.Join(fields, " "))
+		}
+		table.substitutions[fields[0]] = fields[1]
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "invalid sorting code substitution table")
+	}
+	return table, nil
+}
+
+// LoadModulusTable reads weight table and optional substitution table from files, see ParseModulusTable.
+func LoadModulusTable(weightsPath, substitutionsPath string) (*ModulusTable, error) {
+	weights, err := os.Open(weightsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer weights.Close()
+	var substitutions io.Reader
+	if substitutionsPath != "" {
+		file, err := os.Open(substitutionsPath)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		substitutions = file
+	}
+	return ParseModulusTable(weights, substitutions)
+}
+
+// SetModulusTable replaces table used by Builder.Validate and Account.UnmarshalJSON for GB accounts,
+// e.g. with tables Vocalink published after the SDK release, see LoadModulusTable.
+// Nil table turns modulus checking off, DefaultModulusTable restores the bundled one.
+func SetModulusTable(table *ModulusTable) {
+	modulusTableMu.Lock()
+	defer modulusTableMu.Unlock()
+	modulusTable = table
+}
+
+// returns nil if no table is set.
+func currentModulusTable() *ModulusTable {
+	modulusTableMu.RLock()
+	defer modulusTableMu.RUnlock()
+	return modulusTable
+}
+
+// Check validates UK sort code and account number pair.
+// Account numbers of 6 and 7 digits are padded with leading zeros.
+// Pairs whose sort code is not in the table cannot be checked and are valid.
+func (t *ModulusTable) Check(sortCode, accountNumber string) error {
+	if !isSortCode(sortCode) {
+		return errors.Errorf("sort code %s must be 6 digits", sortCode)
+	}
+	if !isDigits(accountNumber) || len(accountNumber) < 6 || len(accountNumber) > 8 {
+		return errors.Errorf("account number %s must be 6 to 8 digits", accountNumber)
+	}
+	accountNumber = strings.Repeat("0", 8-len(accountNumber)) + accountNumber
+	if !t.valid(sortCode, accountNumber) {
+		return errors.Errorf("account number %s fails modulus check for sort code %s", accountNumber, sortCode)
+	}
+	return nil
+}
+
+///////
+// Exceptions follow Vocalink "Validating account numbers" specification.
+// Where two checks apply both must pass, unless exception says otherwise.
+///////
+func (t *ModulusTable) valid(sortCode, accountNumber string) bool {
+	rows := t.rowsFor(sortCode)
+	if len(rows) == 0 {
+		return true
+	}
+	first := rows[0]
+	a, g, h := accountNumber[0], accountNumber[6], accountNumber[7]
+
+	// foreign currency accounts cannot be checked
+	if first.exception == 6 && a >= '4' && a <= '8' && g == h {
+		return true
+	}
+	firstValid := t.check(first, sortCode, accountNumber)
+	if len(rows) == 1 {
+		return firstValid
+	}
+	second := rows[1]
+	switch first.exception {
+	case 2:
+		if firstValid {
+			return true
+		}
+		// second check is carried out with substitute sort code
+		return t.check(second, "309634", accountNumber)
+	case 10, 12:
+		return firstValid || t.check(second, sortCode, accountNumber)
+	}
+	if !firstValid {
+		return false
+	}
+	// c is the 3rd digit of the account number
+	if second.exception == 3 && (accountNumber[2] == '6' || accountNumber[2] == '9') {
+		return true
+	}
+	return t.check(second, sortCode, accountNumber)
+}
+
+// returns up to two rows which sort code falls into, in table order.
+func (t *ModulusTable) rowsFor(sortCode string) []modulusWeight {
+	rows := make([]modulusWeight, 0, 2)
+	for _, row := range t.weights {
+		if sortCode >= row.from && sortCode <= row.to {
+			rows = append(rows, row)
+			if len(rows) == 2 {
+				break
+			}
+		}
+	}
+	return rows
+}
+
+func (t *ModulusTable) check(row modulusWeight, sortCode, accountNumber string) bool {
+	weights := row.weights
+	switch row.exception {
+	case 5:
+		if substitute, ok := t.substitutions[sortCode]; ok {
+			sortCode = substitute
+		}
+	case 8:
+		sortCode = "090126"
+	case 2:
+		if accountNumber[0] != '0' {
+			if accountNumber[6] == '9' {
+				weights = [14]int{0, 0, 0, 0, 0, 0, 0, 0, 8, 7, 10, 9, 3, 1}
+			} else {
+				weights = [14]int{0, 0, 1, 2, 5, 3, 6, 4, 8, 7, 10, 9, 3, 1}
+			}
+		}
+	case 7:
+		if accountNumber[6] == '9' {
+			zeroiseUToB(&weights)
+		}
+	case 10:
+		if (accountNumber[:2] == "09" || accountNumber[:2] == "99") && accountNumber[6] == '9' {
+			zeroiseUToB(&weights)
+		}
+	}
+
+	digits := sortCode + accountNumber
+	total := 0
+	for i := range weights {
+		product := int(digits[i]-'0') * weights[i]
+		if row.method == doubleAltern {
+			// digits of each product are summed, e.g. 16 counts as 1 + 6
+			product = product/10 + product%10
+		}
+		total += product
+	}
+
+	switch row.method {
+	case modulus10:
+		return total%10 == 0
+	case modulus11:
+		switch row.exception {
+		case 4:
+			checkDigits, _ := strconv.Atoi(accountNumber[6:])
+			return total%11 == checkDigits
+		case 5:
+			g := int(accountNumber[6] - '0')
+			switch remainder := total % 11; remainder {
+			case 0:
+				return g == 0
+			case 1:
+				return false
+			default:
+				return 11-remainder == g
+			}
+		case 14:
+			if total%11 == 0 {
+				return true
+			}
+			// account numbers ending with 0, 1 or 9 can have check digit shifted one position to the left
+			if h := accountNumber[7]; h != '0' && h != '1' && h != '9' {
+				return false
+			}
+			shifted := row
+			shifted.exception = 0
+			return t.check(shifted, sortCode, "0"+accountNumber[:7])
+		}
+		return total%11 == 0
+	case doubleAltern:
+		switch row.exception {
+		case 1:
+			return (total+27)%10 == 0
+		case 5:
+			h := int(accountNumber[7] - '0')
+			if remainder := total % 10; remainder != 0 {
+				return 10-remainder == h
+			}
+			return h == 0
+		}
+		return total%10 == 0
+	}
+	return false
+}
+
+func zeroiseUToB(weights *[14]int) {
+	for i := 0; i < 8; i++ {
+		weights[i] = 0
+	}
+}
+
+func parseModulusWeight(fields []string) (modulusWeight, error) {
+	var row modulusWeight
+	if len(fields) != 17 && len(fields) != 18 {
+		return row, errors.Errorf("expected sort code range, method and 14 weights, got %q", strings.Join(fields, " "))
+	}
+	if !isSortCode(fields[0]) || !isSortCode(fields[1]) {
+		return row, errors.Errorf("invalid sort code range %s - %s", fields[0], fields[1])
+	}
+	row.from, row.to, row.method = fields[0], fields[1], fields[2]
+	if row.method != modulus10 && row.method != modulus11 && row.method != doubleAltern {
+		return row, errors.Errorf("unknown modulus method %s", row.method)
+	}
+	for i := range row.weights {
+		weight, err := strconv.Atoi(fields[3+i])
+		if err != nil {
+			return row, errors.Wrapf(err, "invalid weight of sort code range %s - %s", row.from, row.to)
+		}
+		row.weights[i] = weight
+	}
+	if len(fields) == 18 {
+		exception, err := strconv.Atoi(fields[17])
+		if err != nil {
+			return row, errors.Wrapf(err, "invalid exception of sort code range %s - %s", row.from, row.to)
+		}
+		row.exception = exception
+	}
+	return row, nil
+}
+
+func scanTable(r io.Reader, parse func(fields []string) error) error {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if err := parse(strings.Fields(text)); err != nil {
+			return errors.Wrapf(err, "line %d", line)
+		}
+	}
+	return scanner.Err()
+}
+
+func isSortCode(s string) bool {
+	return len(s) == 6 && isDigits(s)
+}
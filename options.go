@@ -0,0 +1,209 @@
+package account
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultEndpoint   = "/v1/organisation/accounts"
+	defaultHealthPath = "/v1/health"
+	defaultTimeout    = 30 * time.Second
+)
+
+type (
+	// Option configures HTTPClient created with New.
+	Option func(*clientConfig) error
+
+	///////
+	// Options are collected first and validated together,
+	// so that conflicting options are rejected no matter in which order they were provided.
+	///////
+	clientConfig struct {
+		baseURL     string
+		endpoint    string
+		healthPath  string
+		httpClient  *http.Client
+		timeout     time.Duration
+		userAgent   string
+		headers     http.Header
+		retryPolicy *RetryPolicy
+	}
+)
+
+// headers which are set by HTTPClient itself and cannot be overridden by default headers
+var managedHeaders = []string{"Accept", "Content-Type", idempotencyKeyHeader}
+
+// New creates HTTP Client instance configured with provided options.
+// WithBaseURL is required, other options fall back to defaults:
+// accounts endpoint '/v1/organisation/accounts', health path '/v1/health',
+// http client with 30 seconds timeout and DefaultRetryPolicy.
+// Returns error if any option is invalid or options conflict with each other.
+func New(opts ...Option) (*HTTPClient, error) {
+	config := &clientConfig{
+		endpoint:    defaultEndpoint,
+		healthPath:  defaultHealthPath,
+		retryPolicy: DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(config); err != nil {
+			return nil, err
+		}
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	host, _ := url.Parse(config.baseURL)
+	endpoint, _ := url.Parse(config.endpoint)
+	healthPath, _ := url.Parse(config.healthPath)
+	httpClient := config.httpClient
+	if httpClient == nil {
+		timeout := config.timeout
+		if timeout == 0 {
+			timeout = defaultTimeout
+		}
+		httpClient = &http.Client{Timeout: timeout}
+	}
+	return &HTTPClient{
+		httpClient:     httpClient,
+		apiHost:        host,
+		apiEndpoint:    endpoint,
+		healthPath:     healthPath,
+		userAgent:      config.userAgent,
+		defaultHeaders: config.headers,
+		retryPolicy:    config.retryPolicy,
+	}, nil
+}
+
+// WithBaseURL sets absolute URL of the server hosting accounts API, e.g. 'https://api.form3.tech'.
+func WithBaseURL(baseURL string) Option {
+	return func(config *clientConfig) error {
+		host, err := url.Parse(baseURL)
+		if err != nil {
+			return errors.Wrap(err, "invalid base URL")
+		}
+		if (host.Scheme != "http" && host.Scheme != "https") || host.Host == "" {
+			return errors.Errorf("base URL %q must be an absolute http or https URL", baseURL)
+		}
+		config.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithEndpoint sets path of accounts endpoint on the server. Defaults to '/v1/organisation/accounts'.
+func WithEndpoint(endpoint string) Option {
+	return func(config *clientConfig) error {
+		if err := validatePath(endpoint); err != nil {
+			return errors.Wrap(err, "invalid endpoint")
+		}
+		config.endpoint = endpoint
+		return nil
+	}
+}
+
+// WithHealthPath sets path of health endpoint on the server. Defaults to '/v1/health'.
+func WithHealthPath(healthPath string) Option {
+	return func(config *clientConfig) error {
+		if err := validatePath(healthPath); err != nil {
+			return errors.Wrap(err, "invalid health path")
+		}
+		config.healthPath = healthPath
+		return nil
+	}
+}
+
+// WithHTTPClient sets pre configured http client. Cannot be combined with WithTimeout -
+// set timeout on provided client instead.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(config *clientConfig) error {
+		if httpClient == nil {
+			return errors.New("http client must not be nil")
+		}
+		config.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTimeout sets timeout of default http client. Defaults to 30 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(config *clientConfig) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+		config.timeout = timeout
+		return nil
+	}
+}
+
+// WithUserAgent sets User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(config *clientConfig) error {
+		if strings.TrimSpace(userAgent) == "" {
+			return errors.New("user agent must not be empty")
+		}
+		config.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithDefaultHeaders sets headers sent with every request. Can be provided several times - headers are merged.
+// Accept, Content-Type and Idempotency-Key headers are managed by the client and cannot be set.
+func WithDefaultHeaders(headers http.Header) Option {
+	return func(config *clientConfig) error {
+		for _, managed := range managedHeaders {
+			if headers.Get(managed) != "" {
+				return errors.Errorf("%s header is managed by the client and cannot be set", managed)
+			}
+		}
+		if config.headers == nil {
+			config.headers = make(http.Header)
+		}
+		for key, values := range headers {
+			for _, value := range values {
+				config.headers.Add(key, value)
+			}
+		}
+		return nil
+	}
+}
+
+// WithRetryPolicy sets retry policy of the client. Nil disables retries. Defaults to DefaultRetryPolicy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(config *clientConfig) error {
+		config.retryPolicy = policy
+		return nil
+	}
+}
+
+// checks options which are only invalid in combination.
+func (config *clientConfig) validate() error {
+	if config.baseURL == "" {
+		return errors.New("base URL is required. Use WithBaseURL option")
+	}
+	if config.httpClient != nil && config.timeout != 0 {
+		return errors.New("WithTimeout cannot be combined with WithHTTPClient. Set timeout on provided http client")
+	}
+	if config.userAgent != "" && config.headers.Get("User-Agent") != "" {
+		return errors.New("User-Agent is set by both WithUserAgent and WithDefaultHeaders")
+	}
+	return nil
+}
+
+func validatePath(path string) error {
+	parsed, err := url.Parse(path)
+	if err != nil {
+		return err
+	}
+	if parsed.IsAbs() || parsed.Host != "" || !strings.HasPrefix(parsed.Path, "/") {
+		return errors.Errorf("%q must be an absolute path without host", path)
+	}
+	return nil
+}
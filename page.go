@@ -0,0 +1,172 @@
+package account
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+type pageKind int
+
+const (
+	pageKindNumber pageKind = iota + 1
+	pageKindFirst
+	pageKindLast
+)
+
+type (
+	// PageRequest describes which page of accounts and of what size should be requested by ListPage.
+	// Use FirstPage, LastPage or PageNumber constructors - zero value is not a valid request.
+	PageRequest struct {
+		kind   pageKind
+		number int
+		size   int
+		filter *Filter
+	}
+
+	// Page represents single page of accounts together with requests for the neighbouring pages.
+	// Requests are parsed from pagination links returned by API and are nil if API did not provide them.
+	Page struct {
+		Accounts []Account
+		Self     *PageRequest
+		First    *PageRequest
+		Last     *PageRequest
+		Next     *PageRequest
+		Prev     *PageRequest
+	}
+)
+
+// FirstPage requests first page of given size.
+func FirstPage(size int) PageRequest {
+	return PageRequest{kind: pageKindFirst, size: size}
+}
+
+// LastPage requests last page of given size.
+func LastPage(size int) PageRequest {
+	return PageRequest{kind: pageKindLast, size: size}
+}
+
+// PageNumber requests page of given size by its number. Pages are numbered from 0.
+func PageNumber(number, size int) PageRequest {
+	return PageRequest{kind: pageKindNumber, number: number, size: size}
+}
+
+// Size returns requested page size.
+func (p PageRequest) Size() int {
+	return p.size
+}
+
+// Number returns requested page number. False is returned for first and last page requests.
+func (p PageRequest) Number() (int, bool) {
+	return p.number, p.kind == pageKindNumber
+}
+
+// String returns value of page[number] parameter - page number or 'first', 'last' tags.
+func (p PageRequest) String() string {
+	switch p.kind {
+	case pageKindFirst:
+		return "first"
+	case pageKindLast:
+		return "last"
+	}
+	return strconv.Itoa(p.number)
+}
+
+func (p PageRequest) validate() error {
+	if p.kind == 0 {
+		return errors.New("page request is not initialised. Use FirstPage, LastPage or PageNumber")
+	}
+	if p.kind == pageKindNumber && p.number < 0 {
+		return errors.New("page number must not be negative")
+	}
+	if p.size < 1 {
+		return errors.New("page size must be a positive number")
+	}
+	return nil
+}
+
+func (p PageRequest) parameters() map[string]string {
+	parameters := make(map[string]string)
+	if p.filter != nil {
+		parameters = p.filter.parameters()
+	}
+	parameters["page[number]"] = p.String()
+	parameters["page[size]"] = strconv.Itoa(p.size)
+	return parameters
+}
+
+// HasNext tells if there is a page after this one.
+func (p *Page) HasNext() bool {
+	return p.Next != nil
+}
+
+// ListPage handles execution of list command against accounts API for a single page.
+// Accepts context and page request which is validated before request is sent.
+// Optionally accepts a single filter which narrows down listed accounts on server side.
+// Returns page of accounts with requests for the neighbouring pages, which can be passed to ListPage again.
+// Neighbouring page requests keep the filter, so it does not need to be provided again.
+func (c *HTTPClient) ListPage(ctx context.Context, page PageRequest, filter ...Filter) (*Page, error) {
+	if err := c.validateClient(); err != nil {
+		return nil, err
+	}
+	if err := page.validate(); err != nil {
+		return nil, err
+	}
+	f, err := singleFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if f != nil {
+		page.filter = f
+	}
+
+	accounts, pageLinks, err := c.listPage(ctx, c.clientAPIRequestURL("", page.parameters()))
+	if err != nil {
+		return nil, err
+	}
+	return &Page{
+		Accounts: accounts,
+		Self:     pageRequestFrom(pageLinks.Self, page.filter),
+		First:    pageRequestFrom(pageLinks.First, page.filter),
+		Last:     pageRequestFrom(pageLinks.Last, page.filter),
+		Next:     pageRequestFrom(pageLinks.Next, page.filter),
+		Prev:     pageRequestFrom(pageLinks.Prev, page.filter),
+	}, nil
+}
+
+// parses pagination link returned by API. Returns nil if link is missing or is not understood.
+// Filter of the original request is carried over, as API does not necessarily keep it in the links.
+func pageRequestFrom(link string, filter *Filter) *PageRequest {
+	if link == "" {
+		return nil
+	}
+	linkURL, err := url.Parse(link)
+	if err != nil {
+		return nil
+	}
+	query := linkURL.Query()
+	size, err := strconv.Atoi(query.Get("page[size]"))
+	if err != nil {
+		return nil
+	}
+	var page PageRequest
+	switch number := query.Get("page[number]"); number {
+	case "first":
+		page = FirstPage(size)
+	case "last":
+		page = LastPage(size)
+	default:
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return nil
+		}
+		page = PageNumber(n, size)
+	}
+	if page.validate() != nil {
+		return nil
+	}
+	page.filter = filter
+	return &page
+}
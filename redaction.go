@@ -0,0 +1,253 @@
+package account
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+///////
+// Accounts end up in logs through %v more often than through any logger call,
+// so personal data is masked by every fmt verb, not only by String.
+// Masked attributes are the ones ValidationError masks, see personalData.
+// fmt methods have value receivers, so accounts stored by value, e.g. in a []Account, are masked as well.
+// Unmasked output has to be asked for explicitly with Unredacted, which makes it visible in code review.
+///////
+
+// redacted replaces values which cannot be partially masked, e.g. identifications.
+const redacted = "[REDACTED]"
+
+type (
+	// attribute name and value as printed by fmt, in order of Account getters.
+	printedAttribute struct {
+		name  string
+		value interface{}
+	}
+
+	// fmt view of account or builder attributes.
+	printedAttributes struct {
+		typeName   string
+		attributes []printedAttribute
+		// printed value is a nil pointer or an uninitialised builder
+		isNil bool
+	}
+)
+
+// String returns account attributes which are set, with personal data masked, e.g.
+// 'Account{ID:... Country:GB BankID:400515 AccountNumber:31****19 Iban:GB33 **** **** 6819}'.
+// Nil account is printed as '<nil>' by fmt.
+func (acc Account) String() string {
+	return acc.printed(false).render(false)
+}
+
+// GoString returns Go syntax representation of account, with personal data masked. Used by %#v.
+func (acc Account) GoString() string {
+	return acc.printed(false).goString()
+}
+
+// Format implements fmt.Formatter, so personal data is masked by every verb:
+// %v and %s print attributes which are set, %+v prints all of them, %#v prints Go syntax and %q a quoted string.
+func (acc Account) Format(f fmt.State, verb rune) {
+	acc.printed(false).Format(f, verb)
+}
+
+// LogFields returns attributes which are set, keyed by getter name, with personal data masked.
+// Ready for structured loggers, e.g. logrus.WithFields(logrus.Fields(acc.LogFields())).
+///////
+// Module targets Go 1.13, so slog.LogValuer cannot be implemented without dropping older Go versions.
+// Once Go 1.21 is required, LogValue can wrap these fields into slog.GroupValue.
+///////
+func (acc *Account) LogFields() map[string]interface{} {
+	return acc.printed(false).fields()
+}
+
+// Unredacted returns view of account which prints personal data as is. Intended for debugging only:
+//
+//	fmt.Printf("%+v", acc.Unredacted())
+func (acc *Account) Unredacted() fmt.Formatter {
+	return acc.printed(true)
+}
+
+// String returns builder attributes which are set, with personal data masked like Account.String.
+func (b Builder) String() string {
+	return b.printed(false).render(false)
+}
+
+// GoString returns Go syntax representation of builder attributes, with personal data masked. Used by %#v.
+func (b Builder) GoString() string {
+	return b.printed(false).goString()
+}
+
+// Format implements fmt.Formatter the same way Account.Format does.
+func (b Builder) Format(f fmt.State, verb rune) {
+	b.printed(false).Format(f, verb)
+}
+
+// LogFields returns builder attributes which are set, with personal data masked like Account.LogFields.
+func (b *Builder) LogFields() map[string]interface{} {
+	return b.printed(false).fields()
+}
+
+// Unredacted returns view of builder attributes which prints personal data as is. Intended for debugging only.
+func (b *Builder) Unredacted() fmt.Formatter {
+	return b.printed(true)
+}
+
+func (b *Builder) printed(unredacted bool) printedAttributes {
+	if b == nil || b.essential == nil || b.optional == nil {
+		return printedAttributes{typeName: "Builder", isNil: true}
+	}
+	attributes := b.account(b.essential.Iban).printed(unredacted)
+	attributes.typeName = "Builder"
+	return attributes
+}
+
+func (acc *Account) printed(unredacted bool) printedAttributes {
+	if acc == nil {
+		return printedAttributes{typeName: "Account", isNil: true}
+	}
+	mask, maskList, maskIban := maskPII, maskPIIList, maskIBAN
+	if unredacted {
+		keep := func(value string) string { return value }
+		mask, maskList, maskIban = keep, func(values []string) []string { return values }, keep
+	}
+	return printedAttributes{typeName: "Account", attributes: []printedAttribute{
+		{"ID", acc.id},
+		{"OrganizationID", acc.organizationID},
+		{"Version", acc.versionIndex},
+		{"CreatedOn", acc.createdOn},
+		{"ModifiedOn", acc.modifiedOn},
+		{"Country", acc.country},
+		{"BaseCurrency", acc.baseCurrency},
+		{"BankID", acc.bankID},
+		{"BankIDCode", acc.bankIDCode},
+		{"AccountNumber", mask(acc.accountNumber)},
+		{"Bic", acc.bic},
+		{"Iban", maskIban(acc.iban)},
+		{"CustomerID", mask(acc.customerID)},
+		{"Title", acc.title},
+		{"FirstName", mask(acc.firstName)},
+		{"BankAccountName", mask(acc.bankAccountName)},
+		{"AltBankAccountNames", maskList(acc.altBankAccountNames)},
+		{"AccountClassification", acc.accountClassification},
+		{"JointAccount", acc.jointAccount},
+		{"AccountMatchingOptOut", acc.accountMatchingOptOut},
+		{"SecondaryIdentification", mask(acc.secondaryIdentification)},
+		{"Name", maskList(acc.name)},
+		{"AlternativeNames", maskList(acc.alternativeNames)},
+		{"Status", acc.status},
+		{"StatusReason", acc.statusReason},
+		{"Switched", acc.switched},
+		{"UserDefinedData", printedUserDefinedData(acc.userDefinedData, mask)},
+		{"ValidationType", acc.validationType},
+		{"ReferenceMask", acc.referenceMask},
+		{"AcceptanceQualifier", acc.acceptanceQualifier},
+		{"ProcessingService", acc.processingService},
+		{"PrivateIdentification", printedIdentification(acc.privateIdentification, unredacted)},
+		{"OrganisationIdentification", printedIdentification(acc.organisationIdentification, unredacted)},
+	}}
+}
+
+// user defined data keys are kept, so it is visible which data is stored.
+func printedUserDefinedData(data []UserDefinedData, mask func(string) string) []UserDefinedData {
+	if data == nil {
+		return nil
+	}
+	printed := make([]UserDefinedData, len(data))
+	for i, item := range data {
+		printed[i] = UserDefinedData{Key: item.Key, Value: mask(item.Value)}
+	}
+	return printed
+}
+
+func printedIdentification(identification interface{}, unredacted bool) interface{} {
+	value := reflect.ValueOf(identification)
+	if value.IsNil() {
+		return nil
+	}
+	if unredacted {
+		return value.Elem().Interface()
+	}
+	return redacted
+}
+
+// Format implements fmt.Formatter, see Account.Format.
+func (p printedAttributes) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		io.WriteString(f, p.goString())
+	case verb == 'v' || verb == 's':
+		io.WriteString(f, p.render(f.Flag('+')))
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", p.render(false))
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, p.render(false))
+	}
+}
+
+// renders attributes as 'Account{Name:value ...}', empty attributes are skipped unless all of them are requested.
+func (p printedAttributes) render(all bool) string {
+	if p.isNil {
+		return "<nil>"
+	}
+	printed := make([]string, 0, len(p.attributes))
+	for _, attribute := range p.attributes {
+		if all || !isEmptyAttribute(attribute.value) {
+			printed = append(printed, fmt.Sprintf("%s:%+v", attribute.name, attribute.value))
+		}
+	}
+	return p.typeName + "{" + strings.Join(printed, " ") + "}"
+}
+
+func (p printedAttributes) goString() string {
+	if p.isNil {
+		return "(*account." + p.typeName + ")(nil)"
+	}
+	printed := make([]string, 0, len(p.attributes))
+	for _, attribute := range p.attributes {
+		if !isEmptyAttribute(attribute.value) {
+			printed = append(printed, fmt.Sprintf("%s:%#v", attribute.name, attribute.value))
+		}
+	}
+	return "&account." + p.typeName + "{" + strings.Join(printed, ", ") + "}"
+}
+
+func (p printedAttributes) fields() map[string]interface{} {
+	fields := make(map[string]interface{})
+	for _, attribute := range p.attributes {
+		if !isEmptyAttribute(attribute.value) {
+			fields[attribute.name] = attribute.value
+		}
+	}
+	return fields
+}
+
+func isEmptyAttribute(value interface{}) bool {
+	return value == nil || isEmpty(reflect.ValueOf(value))
+}
+
+// maskIBAN keeps country code, check digits and last 4 characters of IBAN, e.g. 'GB82 **** **** 5432'.
+// Masked part has fixed length, so IBAN length does not reveal the country's account format.
+// IBANs up to 8 characters are masked completely.
+func maskIBAN(iban string) string {
+	runes := []rune(strings.Replace(iban, " ", "", -1))
+	if len(runes) == 0 {
+		return ""
+	}
+	if len(runes) <= 8 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:4]) + " **** **** " + string(runes[len(runes)-4:])
+}
+
+func maskPIIList(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	masked := make([]string, len(values))
+	for i, value := range values {
+		masked[i] = maskPII(value)
+	}
+	return masked
+}
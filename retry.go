@@ -0,0 +1,192 @@
+package account
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how HTTPClient retries requests which failed with transient errors.
+// Requests are retried on transport errors and on configured response status codes,
+// with exponentially growing, jittered delays between attempts.
+// Only idempotent requests are retried - Create is retried only if idempotency key is set on its context.
+///////
+// Retry-After header sent by the API takes precedence over computed backoff delay,
+// but request is not retried if server asks to wait longer than MaxDelay.
+// Retries never outlive request context - if deadline would pass before next attempt, last result is returned.
+///////
+type RetryPolicy struct {
+	// MaxAttempts is a total number of attempts, including the first one. Values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. Every next retry doubles it.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts.
+	MaxDelay time.Duration
+	// StatusCodes lists response status codes which are considered transient.
+	StatusCodes []int
+}
+
+type idempotencyKeyContext struct{}
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// DefaultRetryPolicy returns retry policy used by HTTPClient unless configured otherwise.
+// Retries up to 3 times on rate limiting and gateway errors, waiting from 100ms up to 5s between attempts.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		StatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithIdempotencyKey returns a copy of provided context which carries idempotency key.
+// Key is sent with the request as Idempotency-Key header and allows Create command to be retried safely.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, idempotencyKeyContext{}, key)
+}
+
+func idempotencyKeyFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	key, _ := ctx.Value(idempotencyKeyContext{}).(string)
+	return key
+}
+
+// SetRetryPolicy replaces retry policy of the client. Setting nil disables retries.
+func (c *HTTPClient) SetRetryPolicy(policy *RetryPolicy) *HTTPClient {
+	c.retryPolicy = policy
+	return c
+}
+
+// executes request and retries it while policy allows and result is considered transient.
+func (c *HTTPClient) send(request *http.Request) (*http.Response, error) {
+	policy := c.retryPolicy
+	if policy == nil || policy.MaxAttempts < 2 || !isIdempotent(request) {
+		return c.httpClient.Do(request)
+	}
+	ctx := request.Context()
+	for attempt := 1; ; attempt++ {
+		response, err := c.httpClient.Do(request)
+		if attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.isTransient(response, err) {
+			return response, err
+		}
+		delay, ok := policy.delay(attempt, response)
+		if !ok || !fitsDeadline(ctx, delay) {
+			return response, err
+		}
+		if response != nil {
+			_, _ = io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+		if request, err = rewind(request); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (policy *RetryPolicy) isTransient(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	for _, code := range policy.StatusCodes {
+		if response.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// returns delay before next attempt and false if server asked to wait longer than policy allows.
+func (policy *RetryPolicy) delay(attempt int, response *http.Response) (time.Duration, bool) {
+	if response != nil {
+		if wait, ok := retryAfter(response.Header.Get("Retry-After")); ok {
+			return wait, wait <= policy.MaxDelay
+		}
+	}
+	backoff := policy.BaseDelay << uint(attempt-1)
+	if backoff <= 0 || backoff > policy.MaxDelay {
+		backoff = policy.MaxDelay
+	}
+	// equal jitter: keeps at least half of the backoff, so retries from many clients spread out but still back off
+	half := backoff / 2
+	if half <= 0 {
+		return backoff, true
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1)), true
+}
+
+// parses Retry-After header, which holds either delay in seconds or HTTP date.
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func isIdempotent(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete, http.MethodPut:
+		return true
+	}
+	return request.Header.Get(idempotencyKeyHeader) != ""
+}
+
+func fitsDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Now().Add(delay).Before(deadline)
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// prepares request for another attempt - body of the previous one has already been consumed.
+func rewind(request *http.Request) (*http.Request, error) {
+	retry := request.WithContext(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
+}
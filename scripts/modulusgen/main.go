@@ -0,0 +1,64 @@
+// Command modulusgen turns Vocalink modulus weight table (valacdos.txt) and sorting code substitution table
+// (scsubtab.txt) into Go source, so the SDK bundles them without go:embed, which Go 1.13 does not have.
+// Run by go generate in the module root, after the current tables published by Vocalink are saved next to it:
+//
+//	go generate ./...
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	account "github.com/r0kas/form3-accountapi-client"
+)
+
+func main() {
+	weightsPath := flag.String("weights", "scripts/modulusgen/valacdos.txt", "Vocalink modulus weight table")
+	substitutionsPath := flag.String("substitutions", "scripts/modulusgen/scsubtab.txt", "Vocalink sorting code substitution table")
+	out := flag.String("out", "modulus_data.go", "generated Go file")
+	flag.Parse()
+
+	weights, err := ioutil.ReadFile(*weightsPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	substitutions, err := ioutil.ReadFile(*substitutionsPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// tables are validated here, so the bundled ones can be parsed without error handling
+	if _, err := account.ParseModulusTable(bytes.NewReader(weights), bytes.NewReader(substitutions)); err != nil {
+		log.Fatal(err)
+	}
+	source, err := generate(weights, substitutions)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(weights, substitutions []byte) ([]byte, error) {
+	var source bytes.Buffer
+	source.WriteString("// Code generated by scripts/modulusgen from Vocalink valacdos.txt and scsubtab.txt. DO NOT EDIT.\n\n")
+	source.WriteString("package account\n\n")
+	source.WriteString("const (\n")
+	for _, table := range []struct {
+		name string
+		data []byte
+	}{{"vocalinkWeights", weights}, {"vocalinkSubstitutions", substitutions}} {
+		text := strings.Replace(string(table.data), "\r\n", "\n", -1)
+		if strings.Contains(text, "`") {
+			return nil, fmt.Errorf("%s cannot contain backticks", table.name)
+		}
+		fmt.Fprintf(&source, "\t%s = `%s`\n", table.name, text)
+	}
+	source.WriteString(")\n")
+	return format.Source(source.Bytes())
+}
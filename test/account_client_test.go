@@ -2,19 +2,25 @@ package test
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/DATA-DOG/godog"
 	"github.com/google/uuid"
+	"golang.org/x/text/currency"
 
 	account "github.com/r0kas/form3-accountapi-client"
+	"github.com/r0kas/form3-accountapi-client/accountfake"
 )
 
 var apiHost string
 var apiEndpoint string
 var apiClient *account.HTTPClient
+var accountsAPI account.AccountsAPI
 var accountBuilder *account.Builder
 var accountCountry account.Country
 var theAccount *account.Account
+var validationErr error
 var accountsList []account.Account
 
 func countryCodeIsEqual(c string) error {
@@ -31,6 +37,11 @@ func setRandomAccountID() error {
 	return nil
 }
 
+func setAccountID(id string) error {
+	accountBuilder.SetID(id)
+	return nil
+}
+
 func setRandomOrganizationID() error {
 	accountBuilder.SetOrganizationID(uuid.New().String())
 	return nil
@@ -52,13 +63,223 @@ func isValidAccount() (err error) {
 }
 
 func isInvalidAccount() error {
-	_, expectingAnError := accountBuilder.Validate()
-	if expectingAnError != nil {
+	_, validationErr = accountBuilder.Validate()
+	if validationErr != nil {
 		return nil
 	}
 	return errors.New("account is valid")
 }
 
+func validateAccount() error {
+	theAccount, validationErr = accountBuilder.Validate()
+	return nil
+}
+
+func validationErrors() (account.ValidationErrors, error) {
+	var validationErrs account.ValidationErrors
+	if !errors.As(validationErr, &validationErrs) {
+		return nil, fmt.Errorf("expected validation errors, got %v", validationErr)
+	}
+	return validationErrs, nil
+}
+
+func validationReportsErrors(count int) error {
+	if count == 0 && validationErr == nil {
+		return nil
+	}
+	validationErrs, err := validationErrors()
+	if err != nil {
+		return err
+	}
+	if len(validationErrs) != count {
+		return fmt.Errorf("expected %d validation error/s, got %d: %s", count, len(validationErrs), validationErrs.Error())
+	}
+	return nil
+}
+
+func validationErrorHasValue(field, value, country string) error {
+	validationErrs, err := validationErrors()
+	if err != nil {
+		return err
+	}
+	for _, e := range validationErrs {
+		if e.Field == field {
+			if e.Value != value || e.Country.Code() != country {
+				return fmt.Errorf("expected %s value %q of %s, got %q of %s", field, value, country, e.Value, e.Country)
+			}
+			return nil
+		}
+	}
+	return errors.New("no validation error for " + field)
+}
+
+func validationFailsOn(field, rule string) error {
+	validationErrs, err := validationErrors()
+	if err != nil {
+		return err
+	}
+	for _, e := range validationErrs {
+		if e.Field == field && e.Rule == rule {
+			return nil
+		}
+	}
+	return errors.New("unexpected validation errors: " + validationErrs.Error())
+}
+
+func setValidationLocale(locale string) error {
+	accountBuilder.SetLocale(account.Locale(locale))
+	return nil
+}
+
+func validationErrorReads(field, message string) error {
+	validationErrs, err := validationErrors()
+	if err != nil {
+		return err
+	}
+	return validationMessageIs(validationErrs, field, message)
+}
+
+func validationErrorReadsIn(field, message, locale string) error {
+	validationErrs, err := validationErrors()
+	if err != nil {
+		return err
+	}
+	return validationMessageIs(validationErrs.Localize(account.Locale(locale)), field, message)
+}
+
+func validationMessageIs(validationErrs account.ValidationErrors, field, message string) error {
+	for _, e := range validationErrs {
+		if e.Field == field {
+			if e.Error() != message {
+				return fmt.Errorf("expected %s message %q, got %q", field, message, e.Error())
+			}
+			return nil
+		}
+	}
+	return errors.New("no validation error for " + field)
+}
+
+// rules of countries registered by a scenario, as they were before it, restored after the scenario.
+var registeredCountries = make(map[account.Country]*account.CountryRules)
+
+func registerCountry(country, bankIDCode, bankIDRule, baseCurrency string) error {
+	unit, err := currency.ParseISO(baseCurrency)
+	if err != nil {
+		return err
+	}
+	rememberCountryRules(account.Country(country))
+	return account.RegisterCountry(account.Country(country), account.CountryRules{
+		BankIDCode: bankIDCode,
+		BankID:     bankIDRule,
+		Currency:   unit,
+	})
+}
+
+func replaceBankIDRule(country, bankIDRule string) error {
+	rules, ok := account.Country(country).Rules()
+	if !ok {
+		return fmt.Errorf("country %s is not supported", country)
+	}
+	rememberCountryRules(account.Country(country))
+	rules.BankID = bankIDRule
+	return account.RegisterCountry(account.Country(country), rules)
+}
+
+func registerCountryFails(country, bankIDRule string) error {
+	if err := account.RegisterCountry(account.Country(country), account.CountryRules{BankID: bankIDRule}); err == nil {
+		return errors.New("country is registered")
+	}
+	return nil
+}
+
+func countryIsParsed(code, countryCode string) error {
+	country, err := account.ParseCountry(code)
+	if err != nil {
+		return err
+	}
+	if country != account.Country(countryCode) {
+		return fmt.Errorf("expected country %s, got %s", countryCode, country)
+	}
+	return nil
+}
+
+func countryIsNotParsed(code string) error {
+	if _, err := account.ParseCountry(code); err == nil {
+		return errors.New("country is parsed")
+	}
+	return nil
+}
+
+func accountBuilderCannotBeCreated() error {
+	if _, err := account.NewBuilderE(accountCountry); err == nil {
+		return errors.New("account builder is created")
+	}
+	return nil
+}
+
+func countryIsNotSupported(code string) error {
+	if account.Country(code).IsSupported() {
+		return errors.New("country is supported")
+	}
+	return nil
+}
+
+func supportedCountriesAreOrdered() error {
+	countries := account.SupportedCountries()
+	for i := 1; i < len(countries); i++ {
+		if countries[i-1] >= countries[i] {
+			return fmt.Errorf("countries are not ordered: %v", countries)
+		}
+	}
+	return nil
+}
+
+func supportedCountriesAre(codes string) error {
+	supported := make([]string, 0)
+	for _, country := range account.SupportedCountries() {
+		supported = append(supported, country.Code())
+	}
+	if listed := strings.Join(supported, ","); listed != codes {
+		return fmt.Errorf("expected supported countries %s, got %s", codes, listed)
+	}
+	return nil
+}
+
+func restoreRegisteredCountries() error {
+	for country, rules := range registeredCountries {
+		if rules == nil {
+			account.UnregisterCountry(country)
+		} else if err := account.RegisterCountry(country, *rules); err != nil {
+			return err
+		}
+		delete(registeredCountries, country)
+	}
+	return nil
+}
+
+func unregisterCountry(country string) error {
+	rememberCountryRules(account.Country(country))
+	account.UnregisterCountry(account.Country(country))
+	return nil
+}
+
+func rememberCountryRules(country account.Country) {
+	if _, remembered := registeredCountries[country]; remembered {
+		return
+	}
+	registeredCountries[country] = nil
+	if rules, ok := country.Rules(); ok {
+		registeredCountries[country] = &rules
+	}
+}
+
+func accountBaseCurrencyIs(baseCurrency string) error {
+	if baseCurrency != theAccount.BaseCurrency() {
+		return fmt.Errorf("expected base currency %s, got %s", baseCurrency, theAccount.BaseCurrency())
+	}
+	return nil
+}
+
 func accountBankIDCodeIs(bankIDCode string) error {
 	if bankIDCode != theAccount.BankIDCode() {
 		return errors.New("not valid bank ID code")
@@ -66,6 +287,45 @@ func accountBankIDCodeIs(bankIDCode string) error {
 	return nil
 }
 
+func accountBicPartsAre(institution, country, location, branch string) error {
+	bic := account.BIC(theAccount.Bic())
+	if bic.InstitutionCode() != institution || bic.Country().Code() != country ||
+		bic.LocationCode() != location || bic.BranchCode() != branch {
+		return errors.New("not valid bic parts of " + bic.String())
+	}
+	return nil
+}
+
+func loadModulusTable(path string) error {
+	return loadModulusTables(path, "")
+}
+
+func loadModulusTables(weightsPath, substitutionsPath string) error {
+	table, err := account.LoadModulusTable(weightsPath, substitutionsPath)
+	if err != nil {
+		return err
+	}
+	account.SetModulusTable(table)
+	return nil
+}
+
+func turnModulusCheckingOff() error {
+	account.SetModulusTable(nil)
+	return nil
+}
+
+func deriveIban() error {
+	accountBuilder.DeriveIban()
+	return nil
+}
+
+func accountIbanIs(iban string) error {
+	if iban != theAccount.Iban() {
+		return errors.New("not valid iban " + theAccount.Iban())
+	}
+	return nil
+}
+
 func setAccountNumber(number string) error {
 	accountBuilder.SetOptionalAttribute().SetAccountNumber(number)
 	return nil
@@ -147,16 +407,25 @@ func accountBicEquals(bic string) error {
 
 func createAPIClient() (err error) {
 	apiClient, err = account.NewHTTPClient(nil, apiHost, apiEndpoint)
+	accountsAPI = apiClient
 	return
 }
 
+func createFakeAPIClient() error {
+	accountsAPI = accountfake.New()
+	return nil
+}
+
 func createAccount() (err error) {
-	theAccount, err = apiClient.Create(nil, theAccount)
+	theAccount, err = accountsAPI.Create(nil, theAccount)
 	return
 }
 
 func apiHostEquals(hostname string) error {
 	apiHost = hostname
+	if apiHostOverride != "" {
+		apiHost = apiHostOverride
+	}
 	return nil
 }
 
@@ -166,17 +435,17 @@ func apiEndpointEquals(endpoint string) error {
 }
 
 func fetchAccount() (err error) {
-	theAccount, err = apiClient.Fetch(nil, theAccount.ID())
+	theAccount, err = accountsAPI.Fetch(nil, theAccount.ID())
 	return
 }
 
 func deleteAccount() (err error) {
-	err = apiClient.Delete(nil, theAccount.ID(), theAccount.Version())
+	err = accountsAPI.Delete(nil, theAccount.ID(), theAccount.Version())
 	return
 }
 
 func fetchAccountFails() error {
-	_, err := apiClient.Fetch(nil, theAccount.ID())
+	_, err := accountsAPI.Fetch(nil, theAccount.ID())
 	if err != nil {
 		return nil
 	}
@@ -184,13 +453,13 @@ func fetchAccountFails() error {
 }
 
 func listAccounts() (err error) {
-	accountsList, err = apiClient.List(nil, nil)
+	accountsList, err = accountsAPI.List(nil, nil)
 	return
 }
 
 func deleteListedAccounts() error {
 	for _, acc := range accountsList {
-		err := apiClient.Delete(nil, acc.ID(), acc.Version())
+		err := accountsAPI.Delete(nil, acc.ID(), acc.Version())
 		if err != nil {
 			return err
 		}
@@ -230,25 +499,47 @@ func listAccountsWithPageSize(pageNumber string, pageSize int) (err error) {
 		PageNumber: pageNumber,
 		PageSize:   pageSize,
 	}
-	accountsList, err = apiClient.List(nil, pagination)
+	accountsList, err = accountsAPI.List(nil, pagination)
 	return
 }
 
 func createAccountFails() error {
-	_, err := apiClient.Create(nil, theAccount)
-	if err == nil {
+	_, commandErr = accountsAPI.Create(nil, theAccount)
+	if commandErr == nil {
 		return errors.New("expected and error. Got nil")
 	}
 	return nil
 }
 
 func apiClientIsHealthy() error {
-	if apiClient.IsHealthy(nil) {
+	if accountsAPI.IsHealthy(nil) {
 		return nil
 	}
 	return errors.New("API is not healthy")
 }
 
+func createAccountAgain() error {
+	_, commandErr = accountsAPI.Create(nil, theAccount)
+	return nil
+}
+
+func deleteAccountWithVersion(version int) error {
+	commandErr = accountsAPI.Delete(nil, theAccount.ID(), version)
+	return nil
+}
+
+func fetchRandomAccountFromAPI() error {
+	_, commandErr = accountsAPI.Fetch(nil, uuid.New().String())
+	return nil
+}
+
+func accountTimesAreStamped() error {
+	if theAccount.CreatedOn().IsZero() || theAccount.ModifiedOn().IsZero() {
+		return errors.New("account creation and modification times are not set")
+	}
+	return nil
+}
+
 func FeatureContext(s *godog.Suite) {
 	s.Step(`^my country code is "([^"]*)"\$$`, countryCodeIsEqual)
 	s.Step(`^I create an account builder$`, createAccountBuilder)
@@ -258,7 +549,40 @@ func FeatureContext(s *godog.Suite) {
 	s.Step(`^set bic to "([^"]*)"\$$`, setBic)
 	s.Step(`^I have a valid account$`, isValidAccount)
 	s.Step(`^I have an invalid account$`, isInvalidAccount)
+	s.Step(`^validation fails for "([^"]*)" on "([^"]*)" rule$`, validationFailsOn)
+	s.Step(`^validation reports (\d+) error/s$`, validationReportsErrors)
+	s.Step(`^I validate the account$`, validateAccount)
+	s.Step(`^validation error for "([^"]*)" has value "([^"]*)" and country "([^"]*)"$`, validationErrorHasValue)
+	s.Step(`^set account ID to "([^"]*)"$`, setAccountID)
+	s.Step(`^validation messages are in "([^"]*)"$`, setValidationLocale)
+	s.Step(`^validation error for "([^"]*)" reads "([^"]*)"$`, validationErrorReads)
+	s.Step(`^validation error for "([^"]*)" reads "([^"]*)" in "([^"]*)"$`, validationErrorReadsIn)
+	s.Step(`^bank ID rule of country "([^"]*)" is replaced with "([^"]*)"$`, replaceBankIDRule)
+	s.Step(`^country "([^"]*)" is registered with bank ID code "([^"]*)", bank ID rule "([^"]*)" and currency "([^"]*)"$`, registerCountry)
+	s.Step(`^registering country "([^"]*)" with bank ID rule "([^"]*)" fails$`, registerCountryFails)
+	s.Step(`^country code "([^"]*)" is parsed as "([^"]*)"\$$`, countryIsParsed)
+	s.Step(`^country code "([^"]*)" cannot be parsed$`, countryIsNotParsed)
+	s.Step(`^account builder cannot be created$`, accountBuilderCannotBeCreated)
+	s.Step(`^country "([^"]*)" is not supported$`, countryIsNotSupported)
+	s.Step(`^supported countries are ordered by code$`, supportedCountriesAreOrdered)
+	s.Step(`^supported countries are "([^"]*)"$`, supportedCountriesAre)
+	s.Step(`^country "([^"]*)" is unregistered$`, unregisterCountry)
+	s.AfterScenario(func(interface{}, error) {
+		if err := restoreRegisteredCountries(); err != nil {
+			panic(err)
+		}
+	})
+	s.Step(`^account base currency is "([^"]*)"\$$`, accountBaseCurrencyIs)
 	s.Step(`^account bank ID code is "([^"]*)"\$$`, accountBankIDCodeIs)
+	s.Step(`^iban is derived$`, deriveIban)
+	s.Step(`^modulus table is loaded from "([^"]*)"$`, loadModulusTable)
+	s.Step(`^modulus tables are loaded from "([^"]*)" and "([^"]*)"$`, loadModulusTables)
+	s.Step(`^modulus checking is turned off$`, turnModulusCheckingOff)
+	s.AfterScenario(func(interface{}, error) {
+		account.SetModulusTable(account.DefaultModulusTable())
+	})
+	s.Step(`^account bic has institution "([^"]*)", country "([^"]*)", location "([^"]*)" and branch "([^"]*)"\$$`, accountBicPartsAre)
+	s.Step(`^account iban is "([^"]*)"\$$`, accountIbanIs)
 	s.Step(`^set account number to "([^"]*)"\$$`, setAccountNumber)
 	s.Step(`^set first name to "([^"]*)"\$$`, setFirstName)
 	s.Step(`^set alternative bank account name "([^"]*)"\$$`, setAlternativeBankAccountName)
@@ -286,4 +610,15 @@ func FeatureContext(s *godog.Suite) {
 	s.Step(`^I List "([^"]*)" page with Page Size (\d+)$`, listAccountsWithPageSize)
 	s.Step(`^API returns an error on Create command$`, createAccountFails)
 	s.Step(`^api client is healthy$`, apiClientIsHealthy)
+	s.Step(`^fake api client is created$`, createFakeAPIClient)
+	s.Step(`^I run api client Create command again$`, createAccountAgain)
+	s.Step(`^I run api client Delete command for same ID with version (\d+)$`, deleteAccountWithVersion)
+	s.Step(`^I run api client Fetch command for unknown ID$`, fetchRandomAccountFromAPI)
+	s.Step(`^account creation and modification times are set$`, accountTimesAreStamped)
+	apiStubContext(s)
+	attributesContext(s)
+	extraContext(s)
+	encodingContext(s)
+	diffContext(s)
+	redactionContext(s)
 }
@@ -0,0 +1,669 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DATA-DOG/godog"
+	"github.com/DATA-DOG/godog/gherkin"
+	"github.com/google/uuid"
+
+	account "github.com/r0kas/form3-accountapi-client"
+)
+
+// apiStub is a scripted accounts API - it fails first requests with configured status and then succeeds.
+// Written accounts are echoed back with incremented version, fetched accounts are generated.
+type apiStub struct {
+	sync.Mutex
+	server          *httptest.Server
+	failStatus      int
+	failCount       int
+	failMethod      string
+	failed          int
+	retryAfter      string
+	errorMessage    string
+	errorCode       string
+	accounts        int
+	requests        int
+	idempotencyKeys []string
+	queries         []url.Values
+	lastRequest     stubRequest
+	extraJSON       bool
+}
+
+type stubRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Data   struct {
+		ID      string `json:"id"`
+		Version *int   `json:"version"`
+	} `json:"data"`
+	body []byte
+}
+
+const stubEndpoint = "/v1/organisation/accounts"
+
+var stub *apiStub
+var thePage *account.Page
+var theFilter account.Filter
+var commandErr error
+var commandDuration time.Duration
+
+func (a *apiStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.Lock()
+	defer a.Unlock()
+	a.requests++
+	a.idempotencyKeys = append(a.idempotencyKeys, r.Header.Get("Idempotency-Key"))
+	a.queries = append(a.queries, r.URL.Query())
+	body, _ := ioutil.ReadAll(r.Body)
+	a.lastRequest = stubRequest{Method: r.Method, Path: r.URL.Path, Header: r.Header, body: body}
+	_ = json.Unmarshal(body, &a.lastRequest)
+	if a.failed < a.failCount && (a.failMethod == "" || a.failMethod == r.Method) {
+		a.failed++
+		if a.retryAfter != "" {
+			w.Header().Set("Retry-After", a.retryAfter)
+		}
+		w.WriteHeader(a.failStatus)
+		message := a.errorMessage
+		if message == "" {
+			message = "scripted failure"
+		}
+		code := a.errorCode
+		if code == "" {
+			code = strconv.Itoa(a.failStatus)
+		}
+		fmt.Fprintf(w, `{"error_code":"%s","error_message":"%s"}`, code, message)
+		return
+	}
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	switch r.Method {
+	case http.MethodPost, http.MethodPatch:
+		written := map[string]map[string]interface{}{}
+		_ = json.Unmarshal(body, &written)
+		version, _ := written["data"]["version"].(float64)
+		written["data"]["version"] = version + 1
+		if r.Method == http.MethodPost {
+			written["data"]["version"] = 0
+			w.WriteHeader(http.StatusCreated)
+		}
+		_ = json.NewEncoder(w).Encode(written)
+	default:
+		if r.URL.Path == stubEndpoint {
+			a.listAccounts(w, r)
+			return
+		}
+		data := stubAccountJSON(path.Base(r.URL.Path), a.requests)
+		if a.extraJSON {
+			data = withStubExtraJSON(data)
+		}
+		fmt.Fprintf(w, `{"data":%s}`, data)
+	}
+}
+
+// serves page[number] and page[size] query the same way accounts API does, including pagination links.
+func (a *apiStub) listAccounts(w http.ResponseWriter, r *http.Request) {
+	size, err := strconv.Atoi(r.URL.Query().Get("page[size]"))
+	if err != nil || size < 1 {
+		size = 100
+	}
+	last := 0
+	if a.accounts > 0 {
+		last = (a.accounts - 1) / size
+	}
+	number, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+	if r.URL.Query().Get("page[number]") == "last" {
+		number = last
+	}
+	pageLink := func(page int) string {
+		return fmt.Sprintf(`"%s?page%%5Bnumber%%5D=%d&page%%5Bsize%%5D=%d"`, stubEndpoint, page, size)
+	}
+	data := make([]string, 0)
+	for i := number * size; i < a.accounts && i < (number+1)*size; i++ {
+		data = append(data, stubAccountJSON(uuid.New().String(), 0))
+	}
+	links := fmt.Sprintf(`"self":%s,"first":%s,"last":%s`, pageLink(number), pageLink(0), pageLink(last))
+	if number < last {
+		links += fmt.Sprintf(`,"next":%s`, pageLink(number+1))
+	}
+	if number > 0 {
+		links += fmt.Sprintf(`,"prev":%s`, pageLink(number-1))
+	}
+	fmt.Fprintf(w, `{"data":[%s],"links":{%s}}`, strings.Join(data, ","), links)
+}
+
+func stubAccountJSON(id string, version int) string {
+	return fmt.Sprintf(`{"type":"accounts","id":"%s","organisation_id":"%s","version":%d,`+
+		`"attributes":{"country":"BE","bank_id":"123","bank_id_code":"BE","account_classification":"Personal"}}`,
+		id, uuid.New().String(), version)
+}
+
+// adds attributes, relationships and meta which SDK does not model to the account JSON.
+func withStubExtraJSON(accountJSON string) string {
+	accountJSON = strings.Replace(accountJSON, `"attributes":{`,
+		`"attributes":{"settlement_cycle":"T+1","confirmation_of_payee":{"enabled":true},`, 1)
+	return strings.TrimSuffix(accountJSON, "}") +
+		`,"relationships":{"master_account":{"data":[{"type":"accounts","id":"ea6239c1-99e9-4b3b-9c8e-fe5e3f7f1a0b"}]}}` +
+		`,"meta":{"source":"stub"}}`
+}
+
+func startAPIStub() error {
+	stub = &apiStub{}
+	stub.server = httptest.NewServer(stub)
+	return nil
+}
+
+func createAPIClientForStub() (err error) {
+	apiClient, err = account.NewHTTPClient(nil, stub.server.URL, stubEndpoint)
+	if err != nil {
+		return err
+	}
+	accountsAPI = apiClient
+	policy := account.DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 3 * time.Second
+	apiClient.SetRetryPolicy(policy)
+	return nil
+}
+
+func stubRespondsWithStatus(status, count int) error {
+	stub.failStatus = status
+	stub.failCount = count
+	return nil
+}
+
+func stubRespondsWithRetryAfter(status, retryAfter, count int) error {
+	stub.retryAfter = strconv.Itoa(retryAfter)
+	return stubRespondsWithStatus(status, count)
+}
+
+func stubRespondsWithErrorMessage(status int, message string, count int) error {
+	stub.errorMessage = message
+	return stubRespondsWithStatus(status, count)
+}
+
+func stubRespondsWithErrorCode(status int, code, message string, count int) error {
+	stub.errorCode = code
+	return stubRespondsWithErrorMessage(status, message, count)
+}
+
+func stubRespondsToMethodWithErrorCode(status int, code, message string, count int, method string) error {
+	stub.failMethod = method
+	return stubRespondsWithErrorCode(status, code, message, count)
+}
+
+func stubRespondsToMethodWithErrorMessage(status int, message string, count int, method string) error {
+	stub.failMethod = method
+	return stubRespondsWithErrorMessage(status, message, count)
+}
+
+func stubHasAccounts(count int) error {
+	stub.accounts = count
+	return nil
+}
+
+func stubReturnsExtraJSON() error {
+	stub.extraJSON = true
+	return nil
+}
+
+func timeCommand(command func() error) {
+	start := time.Now()
+	commandErr = command()
+	commandDuration = time.Since(start)
+}
+
+func fetchRandomAccount() error {
+	timeCommand(func() error {
+		acc, err := apiClient.Fetch(nil, uuid.New().String())
+		if err == nil {
+			theAccount = acc
+		}
+		return err
+	})
+	return nil
+}
+
+func fetchRandomAccountWithTimeout(seconds int) error {
+	timeCommand(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(seconds)*time.Second)
+		defer cancel()
+		_, err := apiClient.Fetch(ctx, uuid.New().String())
+		return err
+	})
+	return nil
+}
+
+func randomAccount() (*account.Account, error) {
+	return account.NewBuilder(account.Belgium).
+		SetID(uuid.New().String()).
+		SetOrganizationID(uuid.New().String()).
+		SetBankID("123").
+		Validate()
+}
+
+func createRandomAccount() error {
+	acc, err := randomAccount()
+	if err != nil {
+		return err
+	}
+	timeCommand(func() error {
+		_, err := apiClient.Create(nil, acc)
+		return err
+	})
+	return nil
+}
+
+func createRandomAccountWithIdempotencyKey() error {
+	acc, err := randomAccount()
+	if err != nil {
+		return err
+	}
+	timeCommand(func() error {
+		_, err := apiClient.Create(account.WithIdempotencyKey(context.Background(), uuid.New().String()), acc)
+		return err
+	})
+	return nil
+}
+
+func patchRandomAccountWithVersion(version int) error {
+	acc, err := randomAccount()
+	if err != nil {
+		return err
+	}
+	acc, err = account.CastBuilderFrom(acc).SetOptionalAttribute().SetVersion(version).Validate()
+	if err != nil {
+		return err
+	}
+	timeCommand(func() error {
+		_, err := apiClient.Patch(nil, acc)
+		return err
+	})
+	return nil
+}
+
+func patchTheAccount() error {
+	timeCommand(func() error {
+		_, err := apiClient.Patch(nil, theAccount)
+		return err
+	})
+	return nil
+}
+
+func updateRandomAccountFirstName(firstName string) error {
+	timeCommand(func() (err error) {
+		theAccount, err = apiClient.Update(nil, uuid.New().String(), func(b *account.Builder) error {
+			b.SetOptionalAttribute().SetFirstName(firstName)
+			return nil
+		})
+		return
+	})
+	return nil
+}
+
+func updateRandomAccountFailingModification() error {
+	timeCommand(func() (err error) {
+		_, err = apiClient.Update(nil, uuid.New().String(), func(b *account.Builder) error {
+			return errors.New("modification failed")
+		})
+		return
+	})
+	return nil
+}
+
+func iterateAllAccounts(pageSize int) error {
+	timeCommand(func() error {
+		accountsList = nil
+		it := apiClient.ListAll(nil, pageSize)
+		for it.Next() {
+			accountsList = append(accountsList, *it.Account())
+		}
+		return it.Err()
+	})
+	return nil
+}
+
+func listPage(page account.PageRequest) {
+	timeCommand(func() (err error) {
+		accountsList = nil
+		thePage, err = apiClient.ListPage(nil, page)
+		if err == nil {
+			accountsList = thePage.Accounts
+		}
+		return
+	})
+}
+
+func requestPage(pageNumber string, pageSize int) error {
+	switch pageNumber {
+	case "first":
+		listPage(account.FirstPage(pageSize))
+	case "last":
+		listPage(account.LastPage(pageSize))
+	default:
+		number, err := strconv.Atoi(pageNumber)
+		if err != nil {
+			return err
+		}
+		listPage(account.PageNumber(number, pageSize))
+	}
+	return nil
+}
+
+func requestNextPage() error {
+	if thePage == nil || !thePage.HasNext() {
+		return errors.New("there is no next page")
+	}
+	listPage(*thePage.Next)
+	return nil
+}
+
+func pageHasNext() error {
+	if !thePage.HasNext() {
+		return errors.New("expected next page")
+	}
+	return nil
+}
+
+func pageHasNoNext() error {
+	if thePage.HasNext() {
+		return errors.New("expected no next page")
+	}
+	return nil
+}
+
+func pageLinksAre(first, last string) error {
+	if thePage.First == nil || thePage.First.String() != first {
+		return fmt.Errorf("expected first page %s, got %v", first, thePage.First)
+	}
+	if thePage.Last == nil || thePage.Last.String() != last {
+		return fmt.Errorf("expected last page %s, got %v", last, thePage.Last)
+	}
+	return nil
+}
+
+func filterCountryIs(country string) error {
+	theFilter.Country = account.Country(country)
+	return nil
+}
+
+func filterBankIDIs(bankID string) error {
+	theFilter.BankID = bankID
+	return nil
+}
+
+func filterBankIDCodeIs(bankIDCode string) error {
+	theFilter.BankIDCode = bankIDCode
+	return nil
+}
+
+func filterIbanIs(iban string) error {
+	theFilter.Iban = iban
+	return nil
+}
+
+func listFilteredAccounts() error {
+	timeCommand(func() (err error) {
+		accountsList, err = accountsAPI.List(nil, nil, theFilter)
+		return
+	})
+	return nil
+}
+
+func listAccountsWithTwoFilters() error {
+	timeCommand(func() (err error) {
+		accountsList, err = accountsAPI.List(nil, nil, theFilter, theFilter)
+		return
+	})
+	return nil
+}
+
+func iterateAllFilteredAccounts(pageSize int) error {
+	timeCommand(func() error {
+		accountsList = nil
+		it := apiClient.ListAll(nil, pageSize, theFilter)
+		for it.Next() {
+			accountsList = append(accountsList, *it.Account())
+		}
+		return it.Err()
+	})
+	return nil
+}
+
+func requestFilteredPage(pageNumber, pageSize int) error {
+	timeCommand(func() (err error) {
+		thePage, err = apiClient.ListPage(nil, account.PageNumber(pageNumber, pageSize), theFilter)
+		return
+	})
+	return nil
+}
+
+func stubRequestsHadQueryParameter(key, value string) error {
+	if len(stub.queries) == 0 {
+		return errors.New("api stub server received no requests")
+	}
+	for _, query := range stub.queries {
+		if query.Get(key) != value {
+			return fmt.Errorf("expected %s to be %s, got query %s", key, value, query.Encode())
+		}
+	}
+	return nil
+}
+
+func createAPIClientWithOptions(table *gherkin.DataTable) error {
+	opts := make([]account.Option, 0)
+	for _, row := range table.Rows[1:] {
+		option, value := row.Cells[0].Value, row.Cells[1].Value
+		switch option {
+		case "base url":
+			if value == "stub server" {
+				value = stub.server.URL
+			}
+			opts = append(opts, account.WithBaseURL(value))
+		case "endpoint":
+			opts = append(opts, account.WithEndpoint(value))
+		case "health path":
+			opts = append(opts, account.WithHealthPath(value))
+		case "user agent":
+			opts = append(opts, account.WithUserAgent(value))
+		case "default header":
+			header := strings.SplitN(value, ": ", 2)
+			opts = append(opts, account.WithDefaultHeaders(http.Header{header[0]: []string{header[1]}}))
+		case "timeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, account.WithTimeout(timeout))
+		case "http client":
+			opts = append(opts, account.WithHTTPClient(&http.Client{}))
+		case "no retries":
+			opts = append(opts, account.WithRetryPolicy(nil))
+		default:
+			return fmt.Errorf("unknown option %s", option)
+		}
+	}
+	apiClient, commandErr = account.New(opts...)
+	accountsAPI = apiClient
+	return nil
+}
+
+func stubReceivedHeader(key, value string) error {
+	if got := stub.lastRequest.Header.Get(key); got != value {
+		return fmt.Errorf("expected %s header to be %q, got %q", key, value, got)
+	}
+	return nil
+}
+
+func stubReceivedRequestTo(requestPath string) error {
+	if stub.lastRequest.Path != requestPath {
+		return fmt.Errorf("expected request to %s, got %s", requestPath, stub.lastRequest.Path)
+	}
+	return nil
+}
+
+func stubReceivedRequestUnder(endpoint string) error {
+	if !strings.HasPrefix(stub.lastRequest.Path, endpoint+"/") {
+		return fmt.Errorf("expected request under %s, got %s", endpoint, stub.lastRequest.Path)
+	}
+	return nil
+}
+
+func commandSucceeds() error {
+	return commandErr
+}
+
+func commandFails() error {
+	if commandErr == nil {
+		return errors.New("expected an error. Got nil")
+	}
+	return nil
+}
+
+var sentinels = map[string]error{
+	"not found":        account.ErrNotFound,
+	"conflict":         account.ErrConflict,
+	"version mismatch": account.ErrVersionMismatch,
+	"rate limited":     account.ErrRateLimited,
+	"validation":       account.ErrValidation,
+}
+
+func commandFailsWith(sentinel string) error {
+	if !errors.Is(commandErr, sentinels[sentinel]) {
+		return fmt.Errorf("expected %s error, got: %v", sentinel, commandErr)
+	}
+	return nil
+}
+
+func commandDoesNotFailWith(sentinel string) error {
+	if errors.Is(commandErr, sentinels[sentinel]) {
+		return fmt.Errorf("expected other than %s error, got: %v", sentinel, commandErr)
+	}
+	return nil
+}
+
+func commandFailsWithErrorContaining(message string) error {
+	if commandErr == nil {
+		return errors.New("expected an error. Got nil")
+	}
+	if !strings.Contains(commandErr.Error(), message) {
+		return fmt.Errorf("expected error containing %q, got: %v", message, commandErr)
+	}
+	return nil
+}
+
+func commandFailsWithStatus(status int) error {
+	apiErr := &account.APIError{}
+	if !errors.As(commandErr, &apiErr) {
+		return fmt.Errorf("expected APIError, got: %v", commandErr)
+	}
+	if apiErr.StatusCode != status {
+		return fmt.Errorf("expected status %d, got %d", status, apiErr.StatusCode)
+	}
+	return nil
+}
+
+func stubReceivedRequests(count int) error {
+	if stub.requests != count {
+		return fmt.Errorf("expected %d requests, api stub received %d", count, stub.requests)
+	}
+	return nil
+}
+
+func stubReceivedIdempotencyKey() error {
+	for _, key := range stub.idempotencyKeys {
+		if key == "" || key != stub.idempotencyKeys[0] {
+			return errors.New("idempotency key was not repeated on every request")
+		}
+	}
+	return nil
+}
+
+func stubReceivedRequestWithVersion(method string, version int) error {
+	last := stub.lastRequest
+	if last.Method != method {
+		return fmt.Errorf("expected %s request, got %s", method, last.Method)
+	}
+	if last.Path != stubEndpoint+"/"+last.Data.ID {
+		return fmt.Errorf("request path %s does not point to account %s", last.Path, last.Data.ID)
+	}
+	if last.Data.Version == nil || *last.Data.Version != version {
+		return fmt.Errorf("expected version %d in request body", version)
+	}
+	return nil
+}
+
+func commandTookAtLeast(seconds int) error {
+	if commandDuration < time.Duration(seconds)*time.Second {
+		return fmt.Errorf("command took %s", commandDuration)
+	}
+	return nil
+}
+
+func apiStubContext(s *godog.Suite) {
+	s.AfterScenario(func(interface{}, error) {
+		theFilter = account.Filter{}
+		if stub != nil {
+			stub.server.Close()
+			stub = nil
+		}
+	})
+	s.Step(`^api stub server is started$`, startAPIStub)
+	s.Step(`^api client is created for api stub server$`, createAPIClientForStub)
+	s.Step(`^api stub server responds with status (\d+) to (\d+) request\/s$`, stubRespondsWithStatus)
+	s.Step(`^api stub server responds with status (\d+) and Retry-After (\d+) to (\d+) request\/s$`, stubRespondsWithRetryAfter)
+	s.Step(`^api stub server responds with status (\d+) and error message "([^"]*)" to (\d+) request\/s$`, stubRespondsWithErrorMessage)
+	s.Step(`^api stub server responds with status (\d+), error code "([^"]*)" and error message "([^"]*)" to (\d+) request\/s$`, stubRespondsWithErrorCode)
+	s.Step(`^api stub server responds with status (\d+), error code "([^"]*)" and error message "([^"]*)" to (\d+) "([^"]*)" request\/s$`, stubRespondsToMethodWithErrorCode)
+	s.Step(`^api stub server responds with status (\d+) and error message "([^"]*)" to (\d+) "([^"]*)" request\/s$`, stubRespondsToMethodWithErrorMessage)
+	s.Step(`^api stub server has (\d+) account\/s$`, stubHasAccounts)
+	s.Step(`^api stub server returns accounts with unknown attributes, relationships and meta$`, stubReturnsExtraJSON)
+	s.Step(`^I run api client Fetch command for random ID$`, fetchRandomAccount)
+	s.Step(`^I run api client Fetch command for random ID with (\d+) second\/s timeout$`, fetchRandomAccountWithTimeout)
+	s.Step(`^I run api client Create command for random account$`, createRandomAccount)
+	s.Step(`^I run api client Create command for random account with idempotency key$`, createRandomAccountWithIdempotencyKey)
+	s.Step(`^I run api client Patch command for random account with version (\d+)$`, patchRandomAccountWithVersion)
+	s.Step(`^I run api client Patch command for the account$`, patchTheAccount)
+	s.Step(`^I run api client Update command setting first name to "([^"]*)"$`, updateRandomAccountFirstName)
+	s.Step(`^I run api client Update command with failing modification$`, updateRandomAccountFailingModification)
+	s.Step(`^I iterate over all accounts with Page Size (\d+)$`, iterateAllAccounts)
+	s.Step(`^I request "([^"]*)" page with Page Size (\d+)$`, requestPage)
+	s.Step(`^I request next page$`, requestNextPage)
+	s.Step(`^page has next page$`, pageHasNext)
+	s.Step(`^page has no next page$`, pageHasNoNext)
+	s.Step(`^first page is "([^"]*)" and last page is "([^"]*)"$`, pageLinksAre)
+	s.Step(`^filter country is "([^"]*)"$`, filterCountryIs)
+	s.Step(`^filter bank ID is "([^"]*)"$`, filterBankIDIs)
+	s.Step(`^filter bank ID code is "([^"]*)"$`, filterBankIDCodeIs)
+	s.Step(`^filter iban is "([^"]*)"$`, filterIbanIs)
+	s.Step(`^I List accounts with filter$`, listFilteredAccounts)
+	s.Step(`^I List accounts with 2 filters$`, listAccountsWithTwoFilters)
+	s.Step(`^I iterate over all accounts with Page Size (\d+) and filter$`, iterateAllFilteredAccounts)
+	s.Step(`^I request page (\d+) with Page Size (\d+) and filter$`, requestFilteredPage)
+	s.Step(`^every api stub server request had "([^"]*)" set to "([^"]*)"$`, stubRequestsHadQueryParameter)
+	s.Step(`^I create api client with options:$`, createAPIClientWithOptions)
+	s.Step(`^api stub server received "([^"]*)" header set to "([^"]*)"$`, stubReceivedHeader)
+	s.Step(`^api stub server received request to "([^"]*)"$`, stubReceivedRequestTo)
+	s.Step(`^api stub server received request under "([^"]*)"$`, stubReceivedRequestUnder)
+	s.Step(`^api client command succeeds$`, commandSucceeds)
+	s.Step(`^api client command fails$`, commandFails)
+	s.Step(`^api client command fails with "([^"]*)" error$`, commandFailsWith)
+	s.Step(`^api client command does not fail with "([^"]*)" error$`, commandDoesNotFailWith)
+	s.Step(`^api client command fails with status (\d+)$`, commandFailsWithStatus)
+	s.Step(`^api client command fails with error containing "([^"]*)"$`, commandFailsWithErrorContaining)
+	s.Step(`^api stub server received (\d+) request\/s$`, stubReceivedRequests)
+	s.Step(`^api stub server received idempotency key on every request$`, stubReceivedIdempotencyKey)
+	s.Step(`^api stub server received "([^"]*)" request for the account with version (\d+)$`, stubReceivedRequestWithVersion)
+	s.Step(`^api client command took at least (\d+) second\/s$`, commandTookAtLeast)
+}
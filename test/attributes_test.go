@@ -0,0 +1,177 @@
+package test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DATA-DOG/godog"
+	"github.com/DATA-DOG/godog/gherkin"
+
+	account "github.com/r0kas/form3-accountapi-client"
+)
+
+// Attribute tables list values the way they are written in features:
+// lists are separated with ';', user defined data is 'key=value',
+// private identification is 'birth date/birth country/identification/address/city/country' and
+// organisation identification is 'identification/actor name/actor birth date/actor residency/address/city/country'.
+
+func setAccountAttributes(table *gherkin.DataTable) error {
+	for _, row := range table.Rows[1:] {
+		if err := setAccountAttribute(row.Cells[0].Value, row.Cells[1].Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setAccountAttribute(attribute, value string) error {
+	optional := accountBuilder.SetOptionalAttribute()
+	switch attribute {
+	case "name":
+		optional.SetName(list(value)...)
+	case "alternative names":
+		optional.SetAlternativeNames(list(value)...)
+	case "status":
+		optional.SetStatus(value)
+	case "status reason":
+		optional.SetStatusReason(value)
+	case "switched":
+		switched, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		optional.SetSwitched(switched)
+	case "user defined data":
+		data := make([]account.UserDefinedData, 0)
+		for _, pair := range list(value) {
+			keyValue := strings.SplitN(pair, "=", 2)
+			data = append(data, account.UserDefinedData{Key: keyValue[0], Value: keyValue[1]})
+		}
+		optional.SetUserDefinedData(data...)
+	case "validation type":
+		optional.SetValidationType(value)
+	case "reference mask":
+		optional.SetReferenceMask(value)
+	case "acceptance qualifier":
+		optional.SetAcceptanceQualifier(value)
+	case "processing service":
+		optional.SetProcessingService(value)
+	case "private identification":
+		parts := fields(value, 6)
+		optional.SetPrivateIdentification(account.PrivateIdentification{
+			BirthDate:      parts[0],
+			BirthCountry:   parts[1],
+			Identification: parts[2],
+			Address:        list(parts[3]),
+			City:           parts[4],
+			Country:        parts[5],
+		})
+	case "organisation identification":
+		parts := fields(value, 7)
+		optional.SetOrganisationIdentification(account.OrganisationIdentification{
+			Identification: parts[0],
+			Actors: []account.Actor{{
+				Name:      list(parts[1]),
+				BirthDate: parts[2],
+				Residency: parts[3],
+			}},
+			Address: list(parts[4]),
+			City:    parts[5],
+			Country: parts[6],
+		})
+	default:
+		return fmt.Errorf("unknown attribute %s", attribute)
+	}
+	return nil
+}
+
+func accountAttributesAre(table *gherkin.DataTable) error {
+	for _, row := range table.Rows[1:] {
+		attribute, expected := row.Cells[0].Value, row.Cells[1].Value
+		actual, err := accountAttribute(theAccount, attribute)
+		if err != nil {
+			return err
+		}
+		if actual != expected {
+			return fmt.Errorf("expected %s %q, got %q", attribute, expected, actual)
+		}
+	}
+	return nil
+}
+
+func accountAttribute(acc *account.Account, attribute string) (string, error) {
+	switch attribute {
+	case "name":
+		return strings.Join(acc.Name(), ";"), nil
+	case "alternative names":
+		return strings.Join(acc.AlternativeNames(), ";"), nil
+	case "status":
+		return acc.Status(), nil
+	case "status reason":
+		return acc.StatusReason(), nil
+	case "switched":
+		return strconv.FormatBool(acc.IsSwitched()), nil
+	case "user defined data":
+		pairs := make([]string, 0)
+		for _, data := range acc.UserDefinedData() {
+			pairs = append(pairs, data.Key+"="+data.Value)
+		}
+		return strings.Join(pairs, ";"), nil
+	case "validation type":
+		return acc.ValidationType(), nil
+	case "reference mask":
+		return acc.ReferenceMask(), nil
+	case "acceptance qualifier":
+		return acc.AcceptanceQualifier(), nil
+	case "processing service":
+		return acc.ProcessingService(), nil
+	case "private identification":
+		id := acc.PrivateIdentification()
+		if id == nil {
+			return "", nil
+		}
+		return strings.Join([]string{id.BirthDate, id.BirthCountry, id.Identification,
+			strings.Join(id.Address, ";"), id.City, id.Country}, "/"), nil
+	case "organisation identification":
+		id := acc.OrganisationIdentification()
+		if id == nil {
+			return "", nil
+		}
+		actor := account.Actor{}
+		if len(id.Actors) > 0 {
+			actor = id.Actors[0]
+		}
+		return strings.Join([]string{id.Identification, strings.Join(actor.Name, ";"), actor.BirthDate, actor.Residency,
+			strings.Join(id.Address, ";"), id.City, id.Country}, "/"), nil
+	}
+	return "", fmt.Errorf("unknown attribute %s", attribute)
+}
+
+func castAccountBuilder() error {
+	accountBuilder = account.CastBuilderFrom(theAccount)
+	return nil
+}
+
+// identification parts are separated with '/', as address and names are lists themselves.
+func fields(value string, count int) []string {
+	parts := strings.Split(value, "/")
+	for len(parts) < count {
+		parts = append(parts, "")
+	}
+	return parts
+}
+
+func list(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ";")
+}
+
+func attributesContext(s *godog.Suite) {
+	s.Step(`^set account attributes:$`, setAccountAttributes)
+	s.Step(`^set account attribute "([^"]*)" to "([^"]*)"$`, setAccountAttribute)
+	s.Step(`^account attributes are:$`, accountAttributesAre)
+	s.Step(`^I cast account builder from the account$`, castAccountBuilder)
+}
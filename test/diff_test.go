@@ -0,0 +1,66 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/DATA-DOG/godog"
+	"github.com/DATA-DOG/godog/gherkin"
+
+	account "github.com/r0kas/form3-accountapi-client"
+)
+
+var originalAccount *account.Account
+var theChanges account.Changes
+
+func keepOriginalAccount() error {
+	originalAccount = theAccount
+	return nil
+}
+
+func diffAccounts() error {
+	theChanges = account.Diff(originalAccount, theAccount)
+	return nil
+}
+
+func thereAreNoChanges() error {
+	if !theChanges.IsEmpty() {
+		return fmt.Errorf("expected no changes, got:\n%s", theChanges.Report())
+	}
+	return nil
+}
+
+func changeReportIs(report *gherkin.DocString) error {
+	if actual := theChanges.Report(); actual != strings.TrimSpace(report.Content) {
+		return fmt.Errorf("expected report:\n%s\ngot:\n%s", report.Content, actual)
+	}
+	return nil
+}
+
+func patchAttributesAre(body *gherkin.DocString) error {
+	actual, err := theChanges.PatchAttributes()
+	if err != nil {
+		return err
+	}
+	var actualAttributes, expectedAttributes interface{}
+	if err := json.Unmarshal(actual, &actualAttributes); err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(body.Content), &expectedAttributes); err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(actualAttributes, expectedAttributes) {
+		return fmt.Errorf("expected patch attributes %s, got %s", body.Content, actual)
+	}
+	return nil
+}
+
+func diffContext(s *godog.Suite) {
+	s.Step(`^I keep the account as the original$`, keepOriginalAccount)
+	s.Step(`^I diff the original and the account$`, diffAccounts)
+	s.Step(`^there are no changes$`, thereAreNoChanges)
+	s.Step(`^report of changes is:$`, changeReportIs)
+	s.Step(`^patch attributes are:$`, patchAttributesAre)
+}
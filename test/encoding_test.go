@@ -0,0 +1,111 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/DATA-DOG/godog"
+	"github.com/DATA-DOG/godog/gherkin"
+	"gopkg.in/yaml.v2"
+
+	account "github.com/r0kas/form3-accountapi-client"
+)
+
+// encodedAccount holds account encoded by the last encoding step.
+var encodedAccount []byte
+
+func encodeAccount(format string) (err error) {
+	switch format {
+	case "JSON":
+		encodedAccount, err = json.Marshal(theAccount)
+	case "flat JSON":
+		encodedAccount, err = theAccount.MarshalFlatJSON()
+	case "YAML":
+		encodedAccount, err = yaml.Marshal(theAccount)
+	case "JSON held by value":
+		encodedAccount, err = encodeHeldByValue(json.Marshal, json.Unmarshal)
+	case "YAML held by value":
+		encodedAccount, err = encodeHeldByValue(yaml.Marshal, yaml.Unmarshal)
+	default:
+		err = fmt.Errorf("unknown format %s", format)
+	}
+	return
+}
+
+// encodes account as a map value, which is not addressable, and returns its encoded part.
+func encodeHeldByValue(marshal func(interface{}) ([]byte, error), unmarshal func([]byte, interface{}) error) ([]byte, error) {
+	encoded, err := marshal(map[string]account.Account{"account": *theAccount})
+	if err != nil {
+		return nil, err
+	}
+	var held map[string]interface{}
+	if err := unmarshal(encoded, &held); err != nil {
+		return nil, err
+	}
+	return marshal(held["account"])
+}
+
+// key is a dot separated path, e.g. 'data.attributes.bank_id'.
+func encodedAccountHas(key, expected string) error {
+	var document interface{}
+	if err := json.Unmarshal(encodedAccount, &document); err != nil {
+		return err
+	}
+	for _, part := range strings.Split(key, ".") {
+		object, ok := document.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s is not found in %s", key, encodedAccount)
+		}
+		document = object[part]
+	}
+	if actual := fmt.Sprint(document); actual != expected {
+		return fmt.Errorf("expected %s %q, got %q", key, expected, actual)
+	}
+	return nil
+}
+
+func decodeAccount(format string) error {
+	return decodeAccountFrom(format, encodedAccount)
+}
+
+func decodeAccountFromDocument(format string, document *gherkin.DocString) error {
+	return decodeAccountFrom(format, []byte(document.Content))
+}
+
+// decoding errors are kept as validation errors, so validation steps can check them.
+func decodeAccountFrom(format string, data []byte) error {
+	decoded := new(account.Account)
+	switch format {
+	case "JSON":
+		validationErr = json.Unmarshal(data, decoded)
+	case "YAML":
+		validationErr = yaml.Unmarshal(data, decoded)
+	default:
+		return fmt.Errorf("unknown format %s", format)
+	}
+	if validationErr == nil {
+		theAccount = decoded
+	}
+	return nil
+}
+
+func decodingSucceeds() error {
+	return validationErr
+}
+
+func accountVersionIs(version int) error {
+	if theAccount.Version() != version {
+		return fmt.Errorf("expected version %d, got %d", version, theAccount.Version())
+	}
+	return nil
+}
+
+func encodingContext(s *godog.Suite) {
+	s.Step(`^I encode the account as "([^"]*)"$`, encodeAccount)
+	s.Step(`^encoded account has "([^"]*)" set to "([^"]*)"$`, encodedAccountHas)
+	s.Step(`^I decode the account from "([^"]*)"$`, decodeAccount)
+	s.Step(`^I decode an account from "([^"]*)":$`, decodeAccountFromDocument)
+	s.Step(`^account is decoded$`, decodingSucceeds)
+	s.Step(`^account version is (\d+)$`, accountVersionIs)
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/DATA-DOG/godog"
+	"github.com/DATA-DOG/godog/gherkin"
+)
+
+// Extra JSON tables name the part as 'attributes.<name>', 'relationships' or 'meta'.
+// Expected JSON is compared compacted, so tables may use any spacing.
+
+func accountExtraIs(table *gherkin.DataTable) error {
+	extra := theAccount.Extra()
+	return extraJSONIs(table, extra.Attributes, extra.Relationships, extra.Meta)
+}
+
+func accountHasNoExtra() error {
+	if extra := theAccount.Extra(); !extra.IsEmpty() {
+		return fmt.Errorf("expected no extra JSON, got %+v", extra)
+	}
+	return nil
+}
+
+func stubReceivedExtra(table *gherkin.DataTable) error {
+	var body struct {
+		Data struct {
+			Attributes    map[string]json.RawMessage `json:"attributes"`
+			Relationships json.RawMessage            `json:"relationships"`
+			Meta          json.RawMessage            `json:"meta"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(stub.lastRequest.body, &body); err != nil {
+		return err
+	}
+	return extraJSONIs(table, body.Data.Attributes, body.Data.Relationships, body.Data.Meta)
+}
+
+func extraJSONIs(table *gherkin.DataTable, attributes map[string]json.RawMessage, relationships, meta json.RawMessage) error {
+	for _, row := range table.Rows[1:] {
+		part, expected := row.Cells[0].Value, row.Cells[1].Value
+		var actual json.RawMessage
+		switch {
+		case part == "relationships":
+			actual = relationships
+		case part == "meta":
+			actual = meta
+		case strings.HasPrefix(part, "attributes."):
+			actual = attributes[strings.TrimPrefix(part, "attributes.")]
+		default:
+			return fmt.Errorf("unknown extra JSON part %s", part)
+		}
+		if compact(actual) != compact(json.RawMessage(expected)) {
+			return fmt.Errorf("expected %s %s, got %s", part, expected, actual)
+		}
+	}
+	return nil
+}
+
+func compact(raw json.RawMessage) string {
+	buffer := new(bytes.Buffer)
+	if err := json.Compact(buffer, raw); err != nil {
+		return string(raw)
+	}
+	return buffer.String()
+}
+
+func extraContext(s *godog.Suite) {
+	s.Step(`^account extra JSON is:$`, accountExtraIs)
+	s.Step(`^account has no extra JSON$`, accountHasNoExtra)
+	s.Step(`^api stub server received extra JSON:$`, stubReceivedExtra)
+}
@@ -13,22 +13,159 @@ Feature: form3 account object
 
     Examples:
       | country_code | bank_id_code | bank_id        | bic           |
-      | "GB"         | "GBDSC"      | "123456"       | "CTBAAU2S"    |
+      | "GB"         | "GBDSC"      | "123456"       | "NWBKGB22"    |
       | "AU"         | "AUBSB"      | ""             | "CTBAAU2SXXX" |
       | "BE"         | "BE"         | "123"          | ""            |
-      | "CA"         | "CACPA"      | ""             | "CTBAAU2S"    |
+      | "CA"         | "CACPA"      | ""             | "ROYCCAT2"    |
       | "FR"         | "FR"         | "0123456789"   | ""            |
       | "DE"         | "DEBLZ"      | "12345678"     | ""            |
       | "GR"         | "GRBIC"      | "1234567"      | ""            |
-      | "HK"         | "HKNCC"      | ""             | "CTBAAU2S"    |
+      | "HK"         | "HKNCC"      | ""             | "HSBCHKHH"    |
       | "IT"         | "ITNCC"      | "01234567890"  | ""            |
       | "LU"         | "LULUX"      | "123"          | ""            |
-      | "NL"         | ""           | ""             | "CTBAAU2S"    |
+      | "NL"         | ""           | ""             | "ABNANL2A"    |
       | "PL"         | "PLKNR"      | "12345678"     | ""            |
       | "PT"         | "PTNCC"      | "12345678"     | ""            |
       | "ES"         | "ESNCC"      | "12345678"     | ""            |
       | "CH"         | "CHBCC"      | "12345"        | ""            |
-      | "US"         | "USABA"      | "123456789"    | "CTBAAU2SXXX" |
+      | "US"         | "USABA"      | "021000021"    | "CHASUS33XXX" |
+
+    Scenario Template: create account with valid iban
+      Given my country code is <country_code>$
+      When I create an account builder
+      And set random account ID
+      And set random organization ID
+      And set bank ID to <bank_id>$
+      And set bic to <bic>$
+      And set iban to <iban>$
+      Then I have a valid account
+      And account iban is <electronic_iban>$
+
+      Examples:
+        | country_code | bank_id       | bic        | iban                               | electronic_iban               |
+        | "GB"         | "123456"      | "NWBKGB22" | "GB82WEST12345698765432"           | "GB82WEST12345698765432"      |
+        | "GB"         | "123456"      | "NWBKGB22" | "GB82 WEST 1234 5698 7654 32"      | "GB82WEST12345698765432"      |
+        | "BE"         | "123"         | ""         | "BE68 5390 0754 7034"              | "BE68539007547034"            |
+        | "FR"         | "0123456789"  | ""         | "FR14 2004 1010 0505 0001 3M02 606" | "FR1420041010050500013M02606" |
+        | "DE"         | "12345678"    | ""         | "DE89370400440532013000"           | "DE89370400440532013000"      |
+        | "GR"         | "1234567"     | ""         | "GR1601101250000000012300695"      | "GR1601101250000000012300695" |
+        | "IT"         | "01234567890" | ""         | "IT60X0542811101000000123456"      | "IT60X0542811101000000123456" |
+        | "LU"         | "123"         | ""         | "LU28 0019 4006 4475 0000"         | "LU280019400644750000"        |
+        | "NL"         | ""            | "ABNANL2A" | "NL91ABNA0417164300"               | "NL91ABNA0417164300"          |
+        | "PL"         | "12345678"    | ""         | "PL61109010140000071219812874"     | "PL61109010140000071219812874" |
+        | "PT"         | "12345678"    | ""         | "PT50000201231234567890154"        | "PT50000201231234567890154"   |
+        | "ES"         | "12345678"    | ""         | "ES91 2100 0418 4502 0005 1332"    | "ES9121000418450200051332"    |
+        | "CH"         | "12345"       | ""         | "CH9300762011623852957"            | "CH9300762011623852957"       |
+
+    Scenario Template: create account with invalid iban
+      Given my country code is <country_code>$
+      When I create an account builder
+      And set random account ID
+      And set random organization ID
+      And set bank ID to <bank_id>$
+      And set bic to <bic>$
+      And set iban to <iban>$
+      Then I have an invalid account
+
+      Examples:
+        | country_code | bank_id      | bic        | iban                          |
+        | "GB"         | "123456"     | "NWBKGB22" | "xxxx"                        |
+        | "GB"         | "123456"     | "NWBKGB22" | "GB83WEST12345698765432"      |
+        | "GB"         | "123456"     | "NWBKGB22" | "GB82WEST1234569876543"       |
+        | "GB"         | "123456"     | "NWBKGB22" | "GB82 1234 1234 5698 7654 32" |
+        | "GB"         | "123456"     | "NWBKGB22" | "DE89370400440532013000"      |
+        | "BE"         | "123"        | ""         | "BE68539007547035"            |
+        | "FR"         | "0123456789" | ""         | "FR1420041010050500013M0260A" |
+        | "DE"         | "12345678"   | ""         | "DE89 3704 0044 0532 0130 01" |
+        | "ES"         | "12345678"   | ""         | "ES9121000418450200051332X"   |
+        | "CH"         | "12345"      | ""         | "CH93-0076-2011-6238-5295-7"  |
+
+    Scenario Template: create account with derived iban
+      Given my country code is <country_code>$
+      When I create an account builder
+      And set random account ID
+      And set random organization ID
+      And set bank ID to <bank_id>$
+      And set bic to <bic>$
+      And set account number to <account_number>$
+      And set iban to <iban>$
+      And iban is derived
+      Then I have a valid account
+      And account iban is <derived_iban>$
+
+      Examples:
+        | country_code | bank_id      | bic        | account_number     | iban                          | derived_iban                   |
+        | "GB"         | "123456"     | "WESTGB22" | "98765432"         | ""                            | "GB82WEST12345698765432"       |
+        | "GB"         | "123456"     | "WESTGB22" | "98765432"         | "GB82 WEST 1234 5698 7654 32" | "GB82WEST12345698765432"       |
+        | "BE"         | "539"        | ""         | "0075470"          | ""                            | "BE68539007547034"             |
+        | "BE"         | "539"        | ""         | "007547034"        | ""                            | "BE68539007547034"             |
+        | "FR"         | "2004101005" | ""         | "0500013M026"      | ""                            | "FR1420041010050500013M02606"  |
+        | "DE"         | "37040044"   | ""         | "532013000"        | ""                            | "DE89370400440532013000"       |
+        | "GR"         | "0110125"    | ""         | "0000000012300695" | ""                            | "GR1601101250000000012300695"  |
+        | "IT"         | "0542811101" | ""         | "000000123456"     | ""                            | "IT60X0542811101000000123456"  |
+        | "LU"         | "001"        | ""         | "9400644750000"    | ""                            | "LU280019400644750000"         |
+        | "NL"         | ""           | "ABNANL2A" | "417164300"        | ""                            | "NL91ABNA0417164300"           |
+        | "PL"         | "10901014"   | ""         | "0000071219812874" | ""                            | "PL61109010140000071219812874" |
+        | "PT"         | "00020123"   | ""         | "12345678901"      | ""                            | "PT50000201231234567890154"    |
+        | "ES"         | "21000418"   | ""         | "0200051332"       | ""                            | "ES9121000418450200051332"     |
+        | "CH"         | "00762"      | ""         | "11623852957"      | ""                            | "CH9300762011623852957"        |
+
+    Scenario Template: iban cannot be derived
+      Given my country code is <country_code>$
+      When I create an account builder
+      And set random account ID
+      And set random organization ID
+      And set bank ID to <bank_id>$
+      And set bic to <bic>$
+      And set account number to <account_number>$
+      And set iban to <iban>$
+      And iban is derived
+      Then I have an invalid account
+
+      Examples:
+        | country_code | bank_id       | bic           | account_number | iban                     |
+        | "GB"         | "123456"      | "WESTGB22"    | ""             | ""                       |
+        | "GB"         | "123456"      | "WESTGB22"    | "98765433"     | "GB82WEST12345698765432" |
+        | "DE"         | "37040044"    | ""            | "12345678901"  | ""                       |
+        | "IT"         | "01234567890" | ""            | "000000123456" | ""                       |
+        | "US"         | "021000021"   | "CHASUS33XXX" | "12345678"     | ""                       |
+
+    Scenario Template: create account with invalid bic
+      Given my country code is <country_code>$
+      When I create an account builder
+      And set random account ID
+      And set random organization ID
+      And set bank ID to <bank_id>$
+      And set bic to <bic>$
+      Then I have an invalid account
+
+      Examples:
+        | country_code | bank_id     | bic           |
+        | "GB"         | "123456"    | "12345678"    |
+        | "GB"         | "123456"    | "DEUTDEFF"    |
+        | "GB"         | "123456"    | "NWBKGB2"     |
+        | "GB"         | "123456"    | "NWBKGB22X"   |
+        | "GB"         | "123456"    | "NWBKGB22-XX" |
+        | "GB"         | "123456"    | "nwbkgb22"    |
+        | "AU"         | ""          | "NWBKGB22XXX" |
+        | "US"         | "021000021" | "1HASUS33"    |
+        | "DE"         | "12345678"  | "NWBKGB22"    |
+
+    Scenario Template: create account with parsed bic
+      Given my country code is <country_code>$
+      When I create an account builder
+      And set random account ID
+      And set random organization ID
+      And set bank ID to <bank_id>$
+      And set bic to <bic>$
+      Then I have a valid account
+      And account bic has institution <institution>, country <country_code>, location <location> and branch <branch>$
+
+      Examples:
+        | country_code | bank_id    | bic           | institution | location | branch |
+        | "GB"         | "123456"   | "NWBKGB22"    | "NWBK"      | "22"     | ""     |
+        | "DE"         | "12345678" | "DEUTDEFF500" | "DEUT"      | "FF"     | "500"  |
+        | "HK"         | ""         | "HSBCHKHHXXX" | "HSBC"      | "HH"     | "XXX"  |
 
     Scenario Template: create invalid account
       Given my country code is <country_code>$
@@ -78,10 +215,10 @@ Feature: form3 account object
 
       Examples:
         | country_code | bank_id_code | bank_id        | bic           | account_number | first_name | business_class | second_id |
-        | "GB"         | "GBDSC"      | "123456"       | "CTBAAU2S"    | "226633"       | "Alice"    | "Business"     | "SomeID"  |
-        | "AU"         | "AUBSB"      | ""             | "CTBAAU2SXXX" | "123453456633" | "Marry"    | "Personal"     | "YourID"  |
-        | "BE"         | "BE"         | "123"          | ""            | "2263453433"   | "Dave"     | "Business"     | "OMG"     |
-        | "CA"         | "CACPA"      | ""             | "CTBAAU2S"    | "333444633"    | "John"     | "Business"     | "NoID"    |
+        | "GB"         | "GBDSC"      | "123456"       | "NWBKGB22"    | "22663312"     | "Alice"    | "Business"     | "SomeID"  |
+        | "AU"         | "AUBSB"      | ""             | "CTBAAU2SXXX" | "1234534566"   | "Marry"    | "Personal"     | "YourID"  |
+        | "BE"         | "BE"         | "123"          | ""            | "2263453"      | "Dave"     | "Business"     | "OMG"     |
+        | "CA"         | "CACPA"      | ""             | "ROYCCAT2"    | "333444633"    | "John"     | "Business"     | "NoID"    |
 
       Scenario Template: create account with invalid optional attributes
         Given my country code is <country_code>$
@@ -99,4 +236,37 @@ Feature: form3 account object
         | "PT"         | "12345678"     | ""            | ""                         | "Random"       |
         | "ES"         | "12345678"     | ""            | ""                         | "Public"       |
         | "CH"         | "12345"        | ""            | ""                         | "Correct"      |
-        | "US"         | "123456789"    | "CTBAAU2SXXX" | "SE3550000000054910000003" | "Personal"     |
+        | "US"         | "021000021"    | "CHASUS33XXX" | "SE3550000000054910000003" | "Personal"     |
+
+    Scenario Template: US bank ID must be ABA routing number
+      Given my country code is "US"$
+      When I create an account builder
+      And set random account ID
+      And set random organization ID
+      And set bank ID to <bank_id>$
+      And set bic to "CHASUS33"$
+      Then I have an invalid account
+      And validation fails for "SetBankID" on <rule> rule
+
+      Examples:
+        | bank_id      | rule    |
+        | "021000022"  | "aba"   |
+        | "021 00002"  | "aba"   |
+        | "131000021"  | "aba"   |
+        | "901000029"  | "aba"   |
+        | "02100002"   | "len=9" |
+
+    Scenario Template: US bank ID is valid ABA routing number
+      Given my country code is "US"$
+      When I create an account builder
+      And set random account ID
+      And set random organization ID
+      And set bank ID to <bank_id>$
+      And set bic to "CHASUS33"$
+      Then I have a valid account
+
+      Examples:
+        | bank_id     |
+        | "021000021" |
+        | "011000015" |
+        | "322271627" |
@@ -0,0 +1,118 @@
+Feature: account number validation
+  Builder must validate account number format of the country and national check digits where they are given
+
+  Scenario Template: Account number in the country format is valid
+    Given my country code is <country_code>$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    And set bic to <bic>$
+    And set account number to <account_number>$
+    Then I have a valid account
+    And account number is <account_number>$
+
+    Examples:
+      | country_code | bank_id      | bic        | account_number      |
+      | "GB"         | "123456"     | "NWBKGB22" | "12345678"          |
+      | "AU"         | ""           | "CTBAAU2S" | "123456"            |
+      | "AU"         | ""           | "CTBAAU2S" | "1234567890"        |
+      | "BE"         | "539"        | ""         | "0075470"           |
+      | "BE"         | "539"        | ""         | "007547034"         |
+      | "CA"         | ""           | "ROYCCAT2" | "1234567"           |
+      | "CA"         | ""           | "ROYCCAT2" | "123456789012"      |
+      | "FR"         | "2004101005" | ""         | "0500013M026"       |
+      | "FR"         | "2004101005" | ""         | "0500013M02606"     |
+      | "DE"         | "37040044"   | ""         | "1234567"           |
+      | "DE"         | "37040044"   | ""         | "0532013000"        |
+      | "GR"         | "0110125"    | ""         | "0000000012300695"  |
+      | "HK"         | ""           | "HSBCHKHH" | "123456789"         |
+      | "IT"         | "0542811101" | ""         | "000000123456"      |
+      | "LU"         | "001"        | ""         | "9400644750000"     |
+      | "NL"         | ""           | "ABNANL2A" | "0417164300"        |
+      | "PL"         | "10901014"   | ""         | "0000071219812874"  |
+      | "PT"         | "00020123"   | ""         | "12345678901"       |
+      | "PT"         | "00020123"   | ""         | "1234567890154"     |
+      | "ES"         | "21000418"   | ""         | "0200051332"        |
+      | "ES"         | "21000418"   | ""         | "450200051332"      |
+      | "CH"         | "00762"      | ""         | "011623852957"      |
+      | "US"         | "021000021"  | "CHASUS33" | "123456"            |
+      | "US"         | "021000021"  | "CHASUS33" | "12345678901234567" |
+
+  Scenario Template: Account number not in the country format is invalid
+    Given my country code is <country_code>$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    And set bic to <bic>$
+    And set account number to <account_number>$
+    Then I have an invalid account
+    And validation reports 1 error/s
+    And validation fails for "SetAccountNumber" on <rule> rule
+
+    Examples:
+      | country_code | bank_id      | bic        | account_number       | rule             |
+      | "GB"         | "123456"     | "NWBKGB22" | "1234567"            | "len=8"          |
+      | "GB"         | "123456"     | "NWBKGB22" | "1234567A"           | "digits"         |
+      | "AU"         | ""           | "CTBAAU2S" | "12345"              | "min=6"          |
+      | "AU"         | ""           | "CTBAAU2S" | "12345678901"        | "max=10"         |
+      | "BE"         | "539"        | ""         | "00754703"           | "len=7\|len=9"   |
+      | "CA"         | ""           | "ROYCCAT2" | "+123456"            | "digits"         |
+      | "FR"         | "2004101005" | ""         | "0500013M02"         | "len=11\|len=13" |
+      | "FR"         | "2004101005" | ""         | "0500013-026"        | "alphanum"       |
+      | "DE"         | "37040044"   | ""         | "123456"             | "min=7"          |
+      | "IT"         | "0542811101" | ""         | "00000012345"        | "len=12"         |
+      | "PL"         | "10901014"   | ""         | "000007121981287"    | "len=16"         |
+      | "US"         | "021000021"  | "CHASUS33" | "123456789012345678" | "max=17"         |
+
+  Scenario Template: Wrong national check digits are invalid
+    Given my country code is <country_code>$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    And set account number to <account_number>$
+    Then I have an invalid account
+    And validation reports 1 error/s
+    And validation fails for "SetAccountNumber" on "check_digits" rule
+    And validation error for "SetAccountNumber" reads "account number has invalid national check digits"
+
+    Examples:
+      | country_code | bank_id      | account_number  |
+      | "BE"         | "539"        | "007547035"     |
+      | "FR"         | "2004101005" | "0500013M02607" |
+      | "FR"         | "2004101005" | "0500013M026AB" |
+      | "PT"         | "00020123"   | "1234567890155" |
+      | "ES"         | "21000418"   | "460200051332"  |
+
+  Scenario: Check digits are not verified when bank ID is invalid
+    Given my country code is "FR"$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "200410100"$
+    And set account number to "0500013M02607"$
+    Then I have an invalid account
+    And validation reports 1 error/s
+    And validation fails for "SetBankID" on "len=10" rule
+
+  Scenario Template: Check digits are not verified against bank ID in other format than the built-in one
+    Given bank ID rule of country <country_code> is replaced with <bank_id_rule>
+    And my country code is <country_code>$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    And set account number to <account_number>$
+    Then I have an invalid account
+    And validation reports 1 error/s
+    And validation fails for "SetAccountNumber" on "check_digits" rule
+
+    Examples:
+      | country_code | bank_id_rule | bank_id        | account_number  |
+      | "FR"         | "len=3"      | "200"          | "0500013M02606" |
+      | "BE"         | "len=2"      | "53"           | "007547034"     |
+      | "PT"         | "len=3"      | "000"          | "1234567890154" |
+      | "ES"         | "len=12"     | "210004181234" | "450200051332"  |
+      | "ES"         | "len=8"      | "2100041A"     | "450200051332"  |
@@ -0,0 +1,112 @@
+Feature: full account attribute coverage
+  Account must carry every attribute of accounts resource through Builder, API and CastBuilderFrom
+
+  Background:
+    Given api host is "http://accountapi:8080"
+    And api endpoint is "/v1/organisation/accounts"
+    Then api client is created
+
+  Scenario: Personal account attributes are kept by API and cast builder
+    Given my country code is "GB"$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "400515"$
+    And set bic to "NWBKGB22"$
+    And set account attributes:
+      | attribute              | value                                                           |
+      | name                   | Jane Doe;Jane Anne Doe                                          |
+      | alternative names      | J Doe;Jane D                                                    |
+      | status                 | confirmed                                                       |
+      | status reason          | unspecified                                                     |
+      | switched               | true                                                            |
+      | user defined data      | crm=42;segment=retail                                           |
+      | validation type        | card                                                            |
+      | reference mask         | ############                                                    |
+      | acceptance qualifier   | same_day                                                        |
+      | processing service     | ABC Bank                                                        |
+      | private identification | 1990-01-31/GB/AB123456C/10 Downing Street;Westminster/London/GB |
+    And I have a valid account
+    When I run api client Create command
+    And I run api client Fetch command for same ID
+    Then account attributes are:
+      | attribute              | value                                                           |
+      | name                   | Jane Doe;Jane Anne Doe                                          |
+      | alternative names      | J Doe;Jane D                                                    |
+      | status                 | confirmed                                                       |
+      | status reason          | unspecified                                                     |
+      | switched               | true                                                            |
+      | user defined data      | crm=42;segment=retail                                           |
+      | validation type        | card                                                            |
+      | reference mask         | ############                                                    |
+      | acceptance qualifier   | same_day                                                        |
+      | processing service     | ABC Bank                                                        |
+      | private identification | 1990-01-31/GB/AB123456C/10 Downing Street;Westminster/London/GB |
+    When I cast account builder from the account
+    And I have a valid account
+    Then account attributes are:
+      | attribute              | value                                                           |
+      | name                   | Jane Doe;Jane Anne Doe                                          |
+      | user defined data      | crm=42;segment=retail                                           |
+      | private identification | 1990-01-31/GB/AB123456C/10 Downing Street;Westminster/London/GB |
+
+  Scenario: Business account attributes are kept by API
+    Given my country code is "GB"$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "400515"$
+    And set bic to "NWBKGB22"$
+    And set business classification to "Business"$
+    And set account attributes:
+      | attribute                   | value                                                    |
+      | name                        | Acme Ltd                                                 |
+      | organisation identification | 01234567/John Smith/1970-05-05/GB/1 Main Street/Leeds/GB |
+    And I have a valid account
+    When I run api client Create command
+    And I run api client Fetch command for same ID
+    Then account attributes are:
+      | attribute                   | value                                                    |
+      | name                        | Acme Ltd                                                 |
+      | organisation identification | 01234567/John Smith/1970-05-05/GB/1 Main Street/Leeds/GB |
+      | private identification      |                                                          |
+
+  Scenario Template: Invalid attribute is reported
+    Given my country code is "GB"$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "400515"$
+    And set bic to "NWBKGB22"$
+    And set account attribute <attribute> to <value>
+    Then I have an invalid account
+    And validation reports 1 error/s
+    And validation fails for <field> on <rule> rule
+
+    Examples:
+      | attribute                     | value                                      | field                           | rule                                  |
+      | "name"                        | "A;B;C;D;E"                                | "SetName"                       | "max=4"                               |
+      | "alternative names"           | "A;B;C;D"                                  | "SetAlternativeNames"           | "max=3"                               |
+      | "status"                      | "open"                                     | "SetStatus"                     | "eq=pending\|eq=confirmed\|eq=closed" |
+      | "user defined data"           | "=42"                                      | "SetUserDefinedData"            | "required"                            |
+      | "validation type"             | "iban"                                     | "SetValidationType"             | "eq=card"                             |
+      | "reference mask"              | "####################################"     | "SetReferenceMask"              | "max=35"                              |
+      | "acceptance qualifier"        | "later"                                    | "SetAcceptanceQualifier"        | "eq=same_day\|eq=next_day"            |
+      | "processing service"          | "Processing service with a very long name" | "SetProcessingService"          | "max=35"                              |
+      | "private identification"      | "31-01-1990/GB////"                        | "SetPrivateIdentification"      | "date"                                |
+      | "private identification"      | "1990-01-31/GBR////"                       | "SetPrivateIdentification"      | "len=2"                               |
+      | "organisation identification" | "01234567/A;B;C;D;E/1970-05-05/GB///"      | "SetOrganisationIdentification" | "max=4"                               |
+
+  Scenario: Private and organisation identification cannot be set together
+    Given my country code is "GB"$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "400515"$
+    And set bic to "NWBKGB22"$
+    And set account attribute "private identification" to "1990-01-31/GB////"
+    And set account attribute "organisation identification" to "01234567//////"
+    Then I have an invalid account
+    And validation reports 1 error/s
+    And validation fails for "SetOrganisationIdentification" on "identification" rule
+    And validation error for "SetOrganisationIdentification" reads "organisation identification cannot be set together with private identification"
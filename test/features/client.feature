@@ -23,22 +23,22 @@ Feature: form3 api client
 
     Examples:
       | country_code | bank_id_code | bank_id        | bic           |
-      | "GB"         | "GBDSC"      | "123456"       | "CTBAAU2S"    |
+      | "GB"         | "GBDSC"      | "123456"       | "NWBKGB22"    |
       | "AU"         | "AUBSB"      | ""             | "CTBAAU2SXXX" |
       | "BE"         | "BE"         | "123"          | ""            |
-      | "CA"         | "CACPA"      | ""             | "CTBAAU2S"    |
+      | "CA"         | "CACPA"      | ""             | "ROYCCAT2"    |
       | "FR"         | "FR"         | "0123456789"   | ""            |
       | "DE"         | "DEBLZ"      | "12345678"     | ""            |
       | "GR"         | "GRBIC"      | "1234567"      | ""            |
-      | "HK"         | "HKNCC"      | ""             | "CTBAAU2S"    |
+      | "HK"         | "HKNCC"      | ""             | "HSBCHKHH"    |
       | "IT"         | "ITNCC"      | "01234567890"  | ""            |
       | "LU"         | "LULUX"      | "123"          | ""            |
-      | "NL"         | ""           | ""             | "CTBAAU2S"    |
+      | "NL"         | ""           | ""             | "ABNANL2A"    |
       | "PL"         | "PLKNR"      | "12345678"     | ""            |
       | "PT"         | "PTNCC"      | "12345678"     | ""            |
       | "ES"         | "ESNCC"      | "12345678"     | ""            |
       | "CH"         | "CHBCC"      | "12345"        | ""            |
-      | "US"         | "USABA"      | "123456789"    | "CTBAAU2SXXX" |
+      | "US"         | "USABA"      | "021000021"    | "CHASUS33XXX" |
 
   Scenario Template: Fetch created account
     Given my country code is <country_code>$
@@ -57,22 +57,22 @@ Feature: form3 api client
 
     Examples:
       | country_code | bank_id_code | bank_id        | bic           |
-      | "GB"         | "GBDSC"      | "123456"       | "CTBAAU2S"    |
+      | "GB"         | "GBDSC"      | "123456"       | "NWBKGB22"    |
       | "AU"         | "AUBSB"      | ""             | "CTBAAU2SXXX" |
       | "BE"         | "BE"         | "123"          | ""            |
-      | "CA"         | "CACPA"      | ""             | "CTBAAU2S"    |
+      | "CA"         | "CACPA"      | ""             | "ROYCCAT2"    |
       | "FR"         | "FR"         | "0123456789"   | ""            |
       | "DE"         | "DEBLZ"      | "12345678"     | ""            |
       | "GR"         | "GRBIC"      | "1234567"      | ""            |
-      | "HK"         | "HKNCC"      | ""             | "CTBAAU2S"    |
+      | "HK"         | "HKNCC"      | ""             | "HSBCHKHH"    |
       | "IT"         | "ITNCC"      | "01234567890"  | ""            |
       | "LU"         | "LULUX"      | "123"          | ""            |
-      | "NL"         | ""           | ""             | "CTBAAU2S"    |
+      | "NL"         | ""           | ""             | "ABNANL2A"    |
       | "PL"         | "PLKNR"      | "12345678"     | ""            |
       | "PT"         | "PTNCC"      | "12345678"     | ""            |
       | "ES"         | "ESNCC"      | "12345678"     | ""            |
       | "CH"         | "CHBCC"      | "12345"        | ""            |
-      | "US"         | "USABA"      | "123456789"    | "CTBAAU2SXXX" |
+      | "US"         | "USABA"      | "021000021"    | "CHASUS33XXX" |
 
   Scenario Template: Delete created account
     Given my country code is <country_code>$
@@ -88,22 +88,44 @@ Feature: form3 api client
 
     Examples:
       | country_code | bank_id        | bic           |
-      | "GB"         | "123456"       | "CTBAAU2S"    |
+      | "GB"         | "123456"       | "NWBKGB22"    |
       | "AU"         | ""             | "CTBAAU2SXXX" |
       | "BE"         | "123"          | ""            |
-      | "CA"         | ""             | "CTBAAU2S"    |
+      | "CA"         | ""             | "ROYCCAT2"    |
       | "FR"         | "0123456789"   | ""            |
       | "DE"         | "12345678"     | ""            |
       | "GR"         | "1234567"      | ""            |
-      | "HK"         | ""             | "CTBAAU2S"    |
+      | "HK"         | ""             | "HSBCHKHH"    |
       | "IT"         | "01234567890"  | ""            |
       | "LU"         | "123"          | ""            |
-      | "NL"         | ""             | "CTBAAU2S"    |
+      | "NL"         | ""             | "ABNANL2A"    |
       | "PL"         | "12345678"     | ""            |
       | "PT"         | "12345678"     | ""            |
       | "ES"         | "12345678"     | ""            |
       | "CH"         | "12345"        | ""            |
-      | "US"         | "123456789"    | "CTBAAU2SXXX" |
+      | "US"         | "021000021"    | "CHASUS33XXX" |
+
+  Scenario Template: API returns an error with wrong input
+    Given api stub server is started
+    And api client is created for api stub server
+    And api stub server responds with status 400 and error message "validation failure" to 1 "POST" request/s
+    And my country code is <country_code>$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    And set bic to <bic>$
+    And I have a valid account
+    When API returns an error on Create command
+    Then api client command fails with "validation" error
+    And api client command fails with status 400
+    And api stub server received 1 request/s
+
+    Examples:
+      | country_code | bank_id        | bic           |
+      | "GB"         | "123456"       | "NWBKGB22"    |
+      | "BE"         | "123"          | ""            |
+      | "FR"         | "0123456789"   | ""            |
 
   Scenario: List, Delete, Create and List accounts again
     Given I List available accounts
@@ -120,28 +142,3 @@ Feature: form3 api client
     When I List "2" page with Page Size 3
     Then I have 3 account/s in my list
 
-  Scenario Template: API returns an error with wrong input
-    Given my country code is <country_code>$
-    And I create an account builder
-    And set random account ID
-    And set random organization ID
-    And set bank ID to <bank_id>$
-    And set bic to <bic>$
-    And set iban to <iban>$
-    And I have a valid account
-    When API returns an error on Create command
-
-    Examples:
-      | country_code | bank_id        | bic           | iban   |
-      | "GB"         | "123456"       | "CTBAAU2S"    | "xxxx" |
-      | "BE"         | "123"          | ""            | "xxxx" |
-      | "FR"         | "0123456789"   | ""            | "xxxx" |
-      | "DE"         | "12345678"     | ""            | "xxxx" |
-      | "GR"         | "1234567"      | ""            | "xxxx" |
-      | "IT"         | "01234567890"  | ""            | "xxxx" |
-      | "LU"         | "123"          | ""            | "xxxx" |
-      | "NL"         | ""             | "CTBAAU2S"    | "xxxx" |
-      | "PL"         | "12345678"     | ""            | "xxxx" |
-      | "PT"         | "12345678"     | ""            | "xxxx" |
-      | "ES"         | "12345678"     | ""            | "xxxx" |
-      | "CH"         | "12345"        | ""            | "xxxx" |
@@ -0,0 +1,106 @@
+Feature: country rules registry
+  Builder must validate accounts with rules of the registered country and reject countries which are not registered
+
+  Scenario Template: Builder starts with base currency of the country
+    Given my country code is <country_code>$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    And set bic to <bic>$
+    Then I have a valid account
+    And account base currency is <currency>$
+
+    Examples:
+      | country_code | bank_id     | bic        | currency |
+      | "GB"         | "400515"    | "NWBKGB22" | "GBP"    |
+      | "CH"         | "12345"     | ""         | "CHF"    |
+      | "PL"         | "12345678"  | ""         | "PLN"    |
+      | "US"         | "021000021" | "CHASUS33" | "USD"    |
+      | "ES"         | "12345678"  | ""         | "EUR"    |
+
+  Scenario: Country which is not registered is rejected
+    Given my country code is "XK"$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    Then I have an invalid account
+    And validation reports 1 error/s
+    And validation fails for "NewBuilder" on "country_supported" rule
+    And validation error for "NewBuilder" reads "country is not supported"
+
+  Scenario Template: Registered country is validated with its rules
+    Given country "LT" is registered with bank ID code "LTBIC", bank ID rule "len=5" and currency "EUR"
+    And my country code is "LT"$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    And set iban to <iban>$
+    And I validate the account
+    Then validation reports <errors> error/s
+
+    Examples:
+      | bank_id | iban                   | errors |
+      | "12345" | ""                     | 0      |
+      | "1234"  | ""                     | 1      |
+      | "12345" | "LT121000011101001000" | 0      |
+      | "12345" | "LT131000011101001000" | 1      |
+
+  Scenario: Registered country has its bank ID code and base currency
+    Given country "LT" is registered with bank ID code "LTBIC", bank ID rule "len=5" and currency "EUR"
+    And my country code is "LT"$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "12345"$
+    Then I have a valid account
+    And account bank ID code is "LTBIC"$
+    And account base currency is "EUR"$
+
+  Scenario: Country with invalid rule cannot be registered
+    Then registering country "LT" with bank ID rule "len=5,unknown" fails
+    And registering country "Lithuania" with bank ID rule "len=5" fails
+
+  Scenario Template: Country is parsed from its code
+    Then country code <code> is parsed as <country_code>$
+
+    Examples:
+      | code   | country_code |
+      | "GB"   | "GB"         |
+      | "gb"   | "GB"         |
+      | " de " | "DE"         |
+      | "US"   | "US"         |
+
+  Scenario Template: Country which is not supported cannot be parsed
+    Then country code <code> cannot be parsed
+
+    Examples:
+      | code  |
+      | "XK"  |
+      | ""    |
+      | "GBR" |
+
+  Scenario: Builder cannot be created for country which is not supported
+    Given my country code is "XK"$
+    Then account builder cannot be created
+    And country "XK" is not supported
+
+  Scenario: Supported countries are listed in order of their codes
+    Then supported countries are ordered by code
+    And supported countries are "AU,BE,CA,CH,DE,ES,FR,GB,GR,HK,IT,LU,NL,PL,PT,US"
+
+  Scenario: Registered country is supported until it is unregistered
+    Given country "LT" is registered with bank ID code "LTBIC", bank ID rule "len=5" and currency "EUR"
+    Then supported countries are "AU,BE,CA,CH,DE,ES,FR,GB,GR,HK,IT,LT,LU,NL,PL,PT,US"
+    When country "LT" is unregistered
+    Then country "LT" is not supported
+
+  Scenario: Unregistered country is rejected by builder
+    Given country "CH" is unregistered
+    And my country code is "CH"$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    Then I have an invalid account
+    And validation fails for "NewBuilder" on "country_supported" rule
@@ -0,0 +1,108 @@
+Feature: account diff
+  Differences between two accounts must be listed attribute by attribute,
+  rendered as minimal PATCH attributes and as human readable report
+
+  Background:
+    Given my country code is "GB"$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "400515"$
+    And set bic to "NWBKGB22"$
+    And set account attributes:
+      | attribute         | value        |
+      | alternative names | J Doe;Jane D |
+      | user defined data | crm=42       |
+    And I have a valid account
+    And I keep the account as the original
+    And I cast account builder from the account
+
+  Scenario: Same accounts have no changes
+    Given I have a valid account
+    When I diff the original and the account
+    Then there are no changes
+
+  Scenario Template: Order and repetition of alternative names do not matter
+    Given set account attribute "alternative names" to "<alternative names>"
+    And I have a valid account
+    When I diff the original and the account
+    Then there are no changes
+
+    Examples:
+      | alternative names   |
+      | Jane D;J Doe        |
+      | J Doe;Jane D;J Doe  |
+      | Jane D;Jane D;J Doe |
+
+  Scenario: Changed attributes are listed with old and new values
+    Given set bank ID to "400516"$
+    And set account attributes:
+      | attribute         | value                 |
+      | alternative names | J Doe;Jane Doe        |
+      | status            | confirmed             |
+      | switched          | true                  |
+      | user defined data | crm=42;segment=retail |
+    And I have a valid account
+    When I diff the original and the account
+    Then report of changes is:
+      """
+      bank_id: "400515" -> "400516"
+      alternative_names: ["J *oe" "Ja** D"] -> ["J *oe" "Ja****oe"]
+      status: none -> "confirmed"
+      switched: false -> true
+      user_defined_data: [{Key:crm Value:**}] -> [{Key:crm Value:**} {Key:segment Value:re**il}]
+      """
+    And patch attributes are:
+      """
+      {
+        "bank_id": "400516",
+        "alternative_names": ["J Doe", "Jane Doe"],
+        "status": "confirmed",
+        "switched": true,
+        "user_defined_data": [{"key": "crm", "value": "42"}, {"key": "segment", "value": "retail"}]
+      }
+      """
+
+  Scenario: Removed attributes are patched with empty values
+    Given set account attributes:
+      | attribute         | value |
+      | alternative names |       |
+      | user defined data |       |
+    And I have a valid account
+    When I diff the original and the account
+    Then report of changes is:
+      """
+      alternative_names: ["J *oe" "Ja** D"] -> none
+      user_defined_data: [{Key:crm Value:**}] -> none
+      """
+    And patch attributes are:
+      """
+      {"alternative_names": null, "user_defined_data": null}
+      """
+
+  Scenario: Personal data is masked in report but not in patch attributes
+    Given set account number to "31926819"$
+    And set account attributes:
+      | attribute              | value                                       |
+      | private identification | 1990-01-31/GB/AB123456C/1 Main St/London/GB |
+    And I have a valid account
+    When I diff the original and the account
+    Then report of changes is:
+      """
+      account_number: none -> "31****19"
+      private_identification: none -> [REDACTED]
+      """
+    And patch attributes are:
+      """
+      {
+        "account_number": "31926819",
+        "private_identification": {
+          "birth_date": "1990-01-31",
+          "birth_country": "GB",
+          "identification": "AB123456C",
+          "address": ["1 Main St"],
+          "city": "London",
+          "country": "GB"
+        }
+      }
+      """
@@ -0,0 +1,126 @@
+Feature: account serialisation
+  Account must be encoded to and decoded from JSON:API envelope, flat JSON and YAML
+  without bypassing Builder validation
+
+  Background:
+    Given fake api client is created
+
+  Scenario Template: Account round trips through <format>
+    Given my country code is "GB"$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "400515"$
+    And set bic to "NWBKGB22"$
+    And set account number to "31926819"$
+    And set account attributes:
+      | attribute         | value                 |
+      | name              | Jane Doe              |
+      | status            | confirmed             |
+      | user defined data | crm=42;segment=retail |
+    And I have a valid account
+    And I run api client Create command
+    When I encode the account as "<format>"
+    And I decode the account from "<decoded from>"
+    Then account is decoded
+    And account bank id is "400515"$
+    And account bic is "NWBKGB22"$
+    And account number is "31926819"$
+    And account attributes are:
+      | attribute         | value                 |
+      | name              | Jane Doe              |
+      | status            | confirmed             |
+      | user defined data | crm=42;segment=retail |
+    And account version is 0
+    And account creation and modification times are set
+
+    Examples:
+      | format             | decoded from |
+      | JSON               | JSON         |
+      | flat JSON          | JSON         |
+      | YAML               | YAML         |
+      | JSON held by value | JSON         |
+      | YAML held by value | YAML         |
+
+  Scenario: JSON keeps attributes in JSON:API envelope
+    Given my country code is "BE"$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "123"$
+    And I have a valid account
+    When I encode the account as "JSON"
+    Then encoded account has "data.type" set to "accounts"
+    And encoded account has "data.attributes.country" set to "BE"
+    And encoded account has "data.attributes.bank_id" set to "123"
+    And encoded account has "data.attributes.switched" set to "false"
+
+  Scenario: Flat JSON keeps attributes next to account ID
+    Given my country code is "BE"$
+    And I create an account builder
+    And set account ID to "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+    And set random organization ID
+    And set bank ID to "123"$
+    And I have a valid account
+    When I encode the account as "flat JSON"
+    Then encoded account has "id" set to "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+    And encoded account has "country" set to "BE"
+    And encoded account has "bank_id" set to "123"
+
+  Scenario: Decoded account keeps unknown attributes
+    When I decode an account from "JSON":
+      """
+      {
+        "id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+        "organisation_id": "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+        "version": 2,
+        "country": "BE",
+        "bank_id": "123",
+        "bank_id_code": "BE",
+        "account_classification": "Personal",
+        "settlement_cycle": "T+1"
+      }
+      """
+    Then account is decoded
+    And account version is 2
+    And account extra JSON is:
+      | part                        | json  |
+      | attributes.settlement_cycle | "T+1" |
+    When I encode the account as "JSON"
+    Then encoded account has "data.attributes.settlement_cycle" set to "T+1"
+
+  Scenario: Account is decoded from YAML envelope
+    When I decode an account from "YAML":
+      """
+      data:
+        type: accounts
+        id: ad27e265-9605-4b4b-a0e5-3003ea9cc4dc
+        organisation_id: eb0bd6f5-c3f5-44b2-b677-acd23cdde73c
+        version: 1
+        attributes:
+          country: GB
+          bank_id: "400515"
+          bank_id_code: GBDSC
+          bic: NWBKGB22
+          account_classification: Personal
+          name:
+            - Jane Doe
+      """
+    Then account is decoded
+    And account version is 1
+    And account bank id is "400515"$
+    And account attributes are:
+      | attribute | value    |
+      | name      | Jane Doe |
+
+  Scenario Template: Invalid account cannot be decoded from <format>
+    When I decode an account from "<format>":
+      """
+      <document>
+      """
+    Then validation fails for "SetBankID" on "len=6" rule
+
+    Examples:
+      | format | document                                                                                                                                                      |
+      | JSON   | {"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","organisation_id":"eb0bd6f5-c3f5-44b2-b677-acd23cdde73c","country":"GB","bank_id":"4005","bank_id_code":"GBDSC"} |
+      | YAML   | {id: ad27e265-9605-4b4b-a0e5-3003ea9cc4dc, organisation_id: eb0bd6f5-c3f5-44b2-b677-acd23cdde73c, country: GB, bank_id: "4005", bank_id_code: GBDSC}          |
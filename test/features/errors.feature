@@ -0,0 +1,44 @@
+Feature: form3 api client errors
+  API Client must return errors which can be told apart without string matching
+
+  Background:
+    Given api stub server is started
+    And api client is created for api stub server
+
+  Scenario Template: API error matches sentinel error
+    Given api stub server responds with status <status> and error message <message> to 1 request/s
+    When I run api client Fetch command for random ID
+    Then api client command fails with status <status>
+    And api client command fails with <sentinel> error
+
+    Examples:
+      | status | message                    | sentinel           |
+      | 404    | "not found"                | "not found"        |
+      | 409    | "duplicate constraint"     | "conflict"         |
+      | 409    | "invalid version"          | "version mismatch" |
+      | 409    | "invalid version"          | "conflict"         |
+      | 400    | "validation failure"       | "validation"       |
+
+  Scenario Template: Version mismatch is told apart by error code
+    Given api stub server responds with status 409, error code <code> and error message <message> to 1 request/s
+    When I run api client Fetch command for random ID
+    Then api client command fails with "conflict" error
+    And api client command <outcome> "version mismatch" error
+
+    Examples:
+      | code               | message                    | outcome            |
+      | "version_mismatch" | "record has been changed"  | fails with         |
+      | "duplicate_id"     | "version 0 already exists" | does not fail with |
+      | "409"              | "invalid version"          | fails with         |
+      | "409"              | "duplicate constraint"     | does not fail with |
+
+  Scenario: Rate limited error is returned when retries run out
+    Given api stub server responds with status 429 and error message "too many requests" to 10 request/s
+    When I run api client Fetch command for random ID
+    Then api client command fails with status 429
+    And api client command fails with "rate limited" error
+
+  Scenario: Create returns API error
+    Given api stub server responds with status 409 and error message "duplicate constraint" to 1 request/s
+    When I run api client Create command for random account
+    Then api client command fails with "conflict" error
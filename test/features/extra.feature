@@ -0,0 +1,51 @@
+Feature: unknown account JSON
+  Attributes, relationships and meta which SDK does not model yet must survive fetch, cast and write round trips
+
+  Background:
+    Given api stub server is started
+    And api client is created for api stub server
+    And api stub server returns accounts with unknown attributes, relationships and meta
+
+  Scenario: Fetched account keeps unknown JSON
+    When I run api client Fetch command for random ID
+    Then api client command succeeds
+    And account extra JSON is:
+      | part                             | json                                                                                          |
+      | attributes.settlement_cycle      | "T+1"                                                                                         |
+      | attributes.confirmation_of_payee | {"enabled":true}                                                                              |
+      | attributes.country               |                                                                                               |
+      | relationships                    | {"master_account":{"data":[{"type":"accounts","id":"ea6239c1-99e9-4b3b-9c8e-fe5e3f7f1a0b"}]}} |
+      | meta                             | {"source":"stub"}                                                                             |
+
+  Scenario: Unknown JSON is sent back when fetched account is patched
+    Given I run api client Fetch command for random ID
+    And I cast account builder from the account
+    And set account attribute "status" to "confirmed"
+    And I have a valid account
+    When I run api client Patch command for the account
+    Then api client command succeeds
+    And api stub server received extra JSON:
+      | part                             | json                                                                                          |
+      | attributes.settlement_cycle      | "T+1"                                                                                         |
+      | attributes.confirmation_of_payee | {"enabled":true}                                                                              |
+      | attributes.status                | "confirmed"                                                                                   |
+      | relationships                    | {"master_account":{"data":[{"type":"accounts","id":"ea6239c1-99e9-4b3b-9c8e-fe5e3f7f1a0b"}]}} |
+      | meta                             | {"source":"stub"}                                                                             |
+
+  Scenario: Update keeps unknown JSON
+    When I run api client Update command setting first name to "Jane"
+    Then api client command succeeds
+    And api stub server received extra JSON:
+      | part                        | json              |
+      | attributes.settlement_cycle | "T+1"             |
+      | attributes.first_name       | "Jane"            |
+      | meta                        | {"source":"stub"} |
+
+  Scenario: Built account has no unknown JSON
+    Given my country code is "BE"$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "123"$
+    When I have a valid account
+    Then account has no extra JSON
@@ -0,0 +1,102 @@
+Feature: form3 in-memory accounts api
+  In-memory AccountsAPI implementation must behave the same way accounts API does
+
+  Background:
+    Given fake api client is created
+    Then api client is healthy
+
+  Scenario Template: Create and fetch account
+    Given my country code is <country_code>$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    And set bic to <bic>$
+    And I have a valid account
+    When I run api client Create command
+    Then account creation and modification times are set
+    When I run api client Fetch command for same ID
+    Then account country code is <country_code>$
+    And account bank ID code is <bank_id_code>$
+    And account bank id is <bank_id>$
+    And account bic is <bic>$
+    And account creation and modification times are set
+
+    Examples:
+      | country_code | bank_id_code | bank_id        | bic           |
+      | "GB"         | "GBDSC"      | "123456"       | "NWBKGB22"    |
+      | "AU"         | "AUBSB"      | ""             | "CTBAAU2SXXX" |
+      | "BE"         | "BE"         | "123"          | ""            |
+      | "US"         | "USABA"      | "021000021"    | "CHASUS33XXX" |
+
+  Scenario: Create account with duplicate ID fails with conflict
+    Given I Create 1 random accounts
+    When I run api client Create command again
+    Then api client command fails with "conflict" error
+
+  Scenario: Fetch unknown account fails with not found
+    When I run api client Fetch command for unknown ID
+    Then api client command fails with "not found" error
+
+  Scenario: Delete account with stale version fails with version mismatch
+    Given I Create 1 random accounts
+    When I run api client Delete command for same ID with version 3
+    Then api client command fails with "version mismatch" error
+    When I run api client Delete command for same ID
+    Then api client command Fetch fails for same ID
+
+  Scenario: Delete unknown account fails with not found
+    Given I Create 1 random accounts
+    And I run api client Delete command for same ID
+    When I run api client Delete command for same ID with version 0
+    Then api client command fails with "not found" error
+
+  Scenario: List, Delete, Create and List accounts again
+    Given I List available accounts
+    And Delete all Listed accounts
+    When I List available accounts
+    And I have 0 account/s in my list
+    Then I Create 10 random accounts
+    When I List available accounts
+    Then I have 10 account/s in my list
+    When I List "first" page with Page Size 3
+    Then I have 3 account/s in my list
+    When I List "last" page with Page Size 3
+    Then I have 1 account/s in my list
+    When I List "2" page with Page Size 3
+    Then I have 3 account/s in my list
+
+  Scenario: List accounts with more than one filter fails
+    Given I Create 1 random accounts
+    When I List accounts with 2 filters
+    Then api client command fails
+
+  Scenario: List accounts with filter IBAN in print format
+    Given my country code is "GB"$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "123456"$
+    And set bic to "NWBKGB22"$
+    And set iban to "GB82WEST12345698765432"$
+    And I have a valid account
+    And I run api client Create command
+    And I Create 2 random accounts
+    And filter iban is "gb82 west 1234 5698 7654 32"
+    When I List accounts with filter
+    Then api client command succeeds
+    And I have 1 account/s in my list
+
+  Scenario Template: List accounts with invalid filter fails
+    Given I Create 1 random accounts
+    And filter country is <country>
+    And filter bank ID is <bank_id>
+    And filter iban is <iban>
+    When I List accounts with filter
+    Then api client command fails
+
+    Examples:
+      | country | bank_id | iban                     |
+      | "XX"    | ""      | ""                       |
+      | "GB"    | "1"     | ""                       |
+      | ""      | ""      | "GB83WEST12345698765432" |
@@ -0,0 +1,73 @@
+Feature: form3 api client filters
+  API Client must filter listed accounts on server side with valid filter values
+
+  Background:
+    Given api stub server is started
+    And api client is created for api stub server
+    And api stub server has 7 account/s
+
+  Scenario Template: List accounts with filter
+    Given filter country is <country>
+    And filter bank ID is <bank_id>
+    And filter bank ID code is <bank_id_code>
+    When I List accounts with filter
+    Then api client command succeeds
+    And every api stub server request had "filter[country]" set to <country>
+    And every api stub server request had "filter[bank_id]" set to <bank_id>
+    And every api stub server request had "filter[bank_id_code]" set to <bank_id_code>
+
+    Examples:
+      | country | bank_id    | bank_id_code |
+      | "GB"    | "400300"   | "GBDSC"      |
+      | "BE"    | "123"      | ""           |
+      | "FR"    | ""         | "FR"         |
+      | ""      | "12345678" | ""           |
+
+  Scenario Template: Invalid filter is rejected before request is sent
+    Given filter country is <country>
+    And filter bank ID is <bank_id>
+    And filter bank ID code is <bank_id_code>
+    And filter iban is <iban>
+    When I List accounts with filter
+    Then api client command fails
+    And api stub server received 0 request/s
+
+    Examples:
+      | country | bank_id | bank_id_code | iban                     |
+      | "XX"    | ""      | ""           | ""                       |
+      | "GB"    | "1"     | ""           | ""                       |
+      | "GB"    | ""      | "DEBLZ"      | ""                       |
+      | "US"    | ""      | ""           | "GB82WEST12345698765432" |
+      | "GB"    | ""      | ""           | "GB83WEST12345698765432" |
+      | ""      | ""      | ""           | "GB83WEST12345698765432" |
+      | ""      | ""      | ""           | "GB82"                   |
+      | ""      | ""      | "XXBLZ"      | ""                       |
+
+  Scenario: IBAN filter is validated without filter country
+    Given filter iban is "GB82WEST12345698765432"
+    And filter bank ID code is "GBDSC"
+    When I List accounts with filter
+    Then api client command succeeds
+    And every api stub server request had "filter[iban]" set to "GB82WEST12345698765432"
+
+  Scenario: More than one filter is rejected
+    Given filter country is "GB"
+    When I List accounts with 2 filters
+    Then api client command fails
+    And api stub server received 0 request/s
+
+  Scenario: Iterator keeps filter on every page
+    Given filter country is "BE"
+    And filter bank ID is "123"
+    When I iterate over all accounts with Page Size 3 and filter
+    Then api client command succeeds
+    And api stub server received 3 request/s
+    And every api stub server request had "filter[bank_id]" set to "123"
+
+  Scenario: Next page request keeps filter
+    Given filter country is "BE"
+    When I request page 0 with Page Size 3 and filter
+    And I request next page
+    Then api client command succeeds
+    And api stub server received 2 request/s
+    And every api stub server request had "filter[country]" set to "BE"
@@ -0,0 +1,33 @@
+Feature: form3 api client account iterator
+  API Client must stream through all accounts following pagination links
+
+  Background:
+    Given api stub server is started
+    And api client is created for api stub server
+
+  Scenario Template: Iterate over all accounts
+    Given api stub server has <accounts> account/s
+    When I iterate over all accounts with Page Size <page_size>
+    Then api client command succeeds
+    And I have <accounts> account/s in my list
+    And api stub server received <requests> request/s
+
+    Examples:
+      | accounts | page_size | requests |
+      | 0        | 3         | 1        |
+      | 3        | 3         | 1        |
+      | 7        | 3         | 3        |
+      | 10       | 1         | 10       |
+      | 10       | 100       | 1        |
+
+  Scenario: Iteration stops on API error
+    Given api stub server has 7 account/s
+    And api stub server responds with status 400 and error message "validation failure" to 1 request/s
+    When I iterate over all accounts with Page Size 3
+    Then api client command fails with "validation" error
+    And I have 0 account/s in my list
+
+  Scenario: Page size must be positive
+    When I iterate over all accounts with Page Size 0
+    Then api client command fails
+    And api stub server received 0 request/s
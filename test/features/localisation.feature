@@ -0,0 +1,60 @@
+Feature: localised validation messages
+  Builder must describe failing attributes in the chosen language using names known to account holders
+
+  Scenario Template: Validation messages are in the Builder's language
+    Given my country code is <country_code>$
+    When I create an account builder
+    And validation messages are in <locale>
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    Then I have an invalid account
+    And validation error for "SetBankID" reads <message>
+
+    Examples:
+      | country_code | bank_id     | locale | message                                                                                     |
+      | "GB"         | "12345"     | "en"   | "sort code must be 6 digits"                                                                |
+      | "GB"         | "12345"     | "de"   | "Sort Code muss 6 Ziffern lang sein"                                                        |
+      | "GB"         | "12345"     | "fr"   | "le sort code doit comporter 6 chiffres"                                                    |
+      | "GB"         | "12345"     | "es"   | "el sort code debe tener 6 dígitos"                                                         |
+      | "GB"         | "12345"     | "pl"   | "sort code: wymagana długość to 6 cyfr"                                                     |
+      | "DE"         | "1234"      | "de"   | "Bankleitzahl muss 8 Ziffern lang sein"                                                     |
+      | "IT"         | "1234"      | "en"   | "bank ID must be 10 or 11 digits"                                                           |
+      | "IT"         | "1234"      | "fr"   | "le code banque doit comporter 10 ou 11 chiffres"                                           |
+      | "NL"         | "1234"      | "en"   | "bank ID is not used in NL"                                                                 |
+      | "US"         | "021000022" | "en"   | "routing number has invalid check digit or Federal Reserve prefix"                          |
+      | "US"         | "021000022" | "es"   | "el routing number tiene un dígito de control o un prefijo de la Reserva Federal no válido" |
+      | "GB"         | "12345"     | "lt"   | "sort code must be 6 digits"                                                                |
+
+  Scenario: Validation messages default to English
+    Given my country code is "GB"$
+    When I create an account builder
+    And set account ID to "not-a-uuid"
+    And set random organization ID
+    And set bank ID to "400515"$
+    And set bic to "DEUTDEFF"$
+    And set iban to "GB83WEST12345698765432"$
+    And set business classification to "Private"$
+    Then I have an invalid account
+    And validation error for "SetID" reads "account ID must be a UUID"
+    And validation error for "SetBic" reads "BIC must belong to a bank in GB"
+    And validation error for "SetIban" reads "IBAN has invalid check digits"
+    And validation error for "SetAccountClassification" reads "account classification must be Personal or Business"
+
+  Scenario Template: Validation messages are translated per call
+    Given my country code is "GB"$
+    When I create an account builder
+    And validation messages are in "en"
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "400515"$
+    And set bic to "NWBKGB22"$
+    And set iban to "GB83WEST12345698765432"$
+    Then I have an invalid account
+    And validation error for "SetIban" reads <message> in <locale>
+
+    Examples:
+      | locale | message                                       |
+      | "de"   | "IBAN hat ungültige Prüfziffern"              |
+      | "fr"   | "l'IBAN a des chiffres de contrôle invalides" |
+      | "pl"   | "IBAN: nieprawidłowe cyfry kontrolne"         |
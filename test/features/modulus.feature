@@ -0,0 +1,81 @@
+Feature: UK sort code modulus checking
+  GB account numbers must pass Vocalink modulus check for their sort code
+
+  Scenario Template: GB account number is checked against sort code
+    Given modulus tables are loaded from "testdata/vocalink_test_valacdos.txt" and "testdata/vocalink_test_scsubtab.txt"
+    And my country code is "GB"$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <sort_code>$
+    And set bic to "NWBKGB22"$
+    And set account number to <account_number>$
+    Then I <outcome> account
+
+    Examples:
+      | sort_code | account_number | outcome           |
+      | "089999"  | "66374958"     | have a valid      |
+      | "107999"  | "88837491"     | have a valid      |
+      | "202959"  | "63748472"     | have a valid      |
+      | "871427"  | "46238510"     | have a valid      |
+      | "872427"  | "46238510"     | have a valid      |
+      | "871427"  | "09123496"     | have a valid      |
+      | "871427"  | "99123496"     | have a valid      |
+      | "820000"  | "73688637"     | have a valid      |
+      | "827999"  | "73988638"     | have a valid      |
+      | "827101"  | "28748352"     | have a valid      |
+      | "134020"  | "63849203"     | have a valid      |
+      | "118765"  | "64371389"     | have a valid      |
+      | "200915"  | "41011166"     | have a valid      |
+      | "938611"  | "07806039"     | have a valid      |
+      | "938600"  | "42368003"     | have a valid      |
+      | "938063"  | "55065200"     | have a valid      |
+      | "772798"  | "99345694"     | have a valid      |
+      | "086090"  | "06774744"     | have a valid      |
+      | "309070"  | "02355688"     | have a valid      |
+      | "309070"  | "12345668"     | have a valid      |
+      | "309070"  | "12345677"     | have a valid      |
+      | "309070"  | "99345694"     | have a valid      |
+      | "938063"  | "15764273"     | have an invalid   |
+      | "938063"  | "15764264"     | have an invalid   |
+      | "938063"  | "15763217"     | have an invalid   |
+      | "118765"  | "64371388"     | have an invalid   |
+      | "203099"  | "66831036"     | have an invalid   |
+      | "203099"  | "58716970"     | have an invalid   |
+      | "089999"  | "66374959"     | have an invalid   |
+      | "107999"  | "88837493"     | have an invalid   |
+      | "074456"  | "12345112"     | have a valid      |
+      | "070116"  | "34012583"     | have a valid      |
+      | "074456"  | "11104102"     | have a valid      |
+      | "180002"  | "00000190"     | have a valid      |
+      | "999999"  | "12345678"     | have a valid      |
+      | "089999"  | "1234567X"     | have an invalid   |
+
+  Scenario Template: Modulus table can be swapped
+    Given modulus table is loaded from "testdata/valacdos.txt"
+    And my country code is "GB"$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <sort_code>$
+    And set bic to "NWBKGB22"$
+    And set account number to <account_number>$
+    Then I <outcome> account
+
+    Examples:
+      | sort_code | account_number | outcome         |
+      | "123456"  | "12345609"     | have a valid    |
+      | "123456"  | "12345678"     | have an invalid |
+      | "089999"  | "66374959"     | have a valid    |
+
+  Scenario: GB account number is not modulus checked when checking is turned off
+    Given modulus tables are loaded from "testdata/vocalink_test_valacdos.txt" and "testdata/vocalink_test_scsubtab.txt"
+    And modulus checking is turned off
+    And my country code is "GB"$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "089999"$
+    And set bic to "NWBKGB22"$
+    And set account number to "66374959"$
+    Then I have a valid account
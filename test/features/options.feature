@@ -0,0 +1,59 @@
+Feature: form3 api client options
+  API Client must be configurable with functional options and reject invalid configuration up front
+
+  Background:
+    Given api stub server is started
+
+  Scenario: Client sends configured headers
+    When I create api client with options:
+      | option         | value                     |
+      | base url       | stub server               |
+      | user agent     | reconciler/1.0            |
+      | default header | X-Correlation-Id: abc-123 |
+      | timeout        | 5s                        |
+    Then api client command succeeds
+    When I run api client Fetch command for random ID
+    Then api client command succeeds
+    And api stub server received "User-Agent" header set to "reconciler/1.0"
+    And api stub server received "X-Correlation-Id" header set to "abc-123"
+    And api stub server received "Accept" header set to "application/vnd.api+json"
+
+  Scenario: Client uses configured endpoint and health path
+    When I create api client with options:
+      | option      | value            |
+      | base url    | stub server      |
+      | endpoint    | /v2/accounts     |
+      | health path | /status          |
+      | http client | default          |
+      | no retries  |                  |
+    Then api client command succeeds
+    And api client is healthy
+    And api stub server received request to "/status"
+    When I run api client Fetch command for random ID
+    Then api stub server received request under "/v2/accounts"
+
+  Scenario: Base URL is required
+    When I create api client with options:
+      | option      | value        |
+      | endpoint    | /v1/accounts |
+      | health path | /v1/health   |
+    Then api client command fails with error containing "base URL is required"
+
+  Scenario Template: Invalid configuration is rejected
+    When I create api client with options:
+      | option   | value         |
+      | base url | stub server   |
+      | <option> | <value>       |
+      | <other>  | <other_value> |
+    Then api client command fails with error containing "<error>"
+
+    Examples:
+      | option      | value           | other          | other_value          | error                                                          |
+      | base url    | accountapi:8080 | endpoint       | /v1/accounts         | must be an absolute http or https URL                          |
+      | base url    | stub server     | endpoint       | http://host/accounts | invalid endpoint                                               |
+      | base url    | stub server     | health path    | health               | invalid health path                                            |
+      | base url    | stub server     | timeout        | -1s                  | timeout must be positive                                       |
+      | base url    | stub server     | user agent     |                      | user agent must not be empty                                   |
+      | http client | default         | timeout        | 5s                   | WithTimeout cannot be combined with WithHTTPClient             |
+      | user agent  | reconciler/1.0  | default header | User-Agent: other    | User-Agent is set by both WithUserAgent and WithDefaultHeaders |
+      | base url    | stub server     | default header | Accept: text/plain   | Accept header is managed by the client                         |
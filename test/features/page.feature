@@ -0,0 +1,43 @@
+Feature: form3 api client pages
+  API Client must list accounts page by page and expose pagination links
+
+  Background:
+    Given api stub server is started
+    And api client is created for api stub server
+    And api stub server has 7 account/s
+
+  Scenario Template: Request page
+    When I request <page> page with Page Size 3
+    Then api client command succeeds
+    And I have <accounts> account/s in my list
+    And first page is "0" and last page is "2"
+
+    Examples:
+      | page    | accounts |
+      | "first" | 3        |
+      | "0"     | 3        |
+      | "1"     | 3        |
+      | "2"     | 1        |
+      | "last"  | 1        |
+      | "3"     | 0        |
+
+  Scenario: Walk pages using next page request
+    When I request "0" page with Page Size 3
+    Then page has next page
+    When I request next page
+    Then page has next page
+    When I request next page
+    Then page has no next page
+    And I have 1 account/s in my list
+    And api stub server received 3 request/s
+
+  Scenario Template: Invalid page request is rejected before it is sent
+    When I request <page> page with Page Size <page_size>
+    Then api client command fails
+    And api stub server received 0 request/s
+
+    Examples:
+      | page    | page_size |
+      | "-1"    | 3         |
+      | "first" | 0         |
+      | "1"     | 0         |
@@ -0,0 +1,28 @@
+Feature: form3 api client patch
+  API Client must update existing accounts
+
+  Background:
+    Given api stub server is started
+    And api client is created for api stub server
+
+  Scenario Template: Patch account
+    When I run api client Patch command for random account with version <version>
+    Then api client command succeeds
+    And api stub server received "PATCH" request for the account with version <version>
+
+    Examples:
+      | version |
+      | 0       |
+      | 3       |
+
+  Scenario: Patch with stale version fails with version mismatch
+    Given api stub server responds with status 409 and error message "invalid version" to 1 request/s
+    When I run api client Patch command for random account with version 1
+    Then api client command fails with "version mismatch" error
+    And api stub server received 1 request/s
+
+  Scenario: Patch is not retried without idempotency key
+    Given api stub server responds with status 503 to 1 request/s
+    When I run api client Patch command for random account with version 1
+    Then api client command fails
+    And api stub server received 1 request/s
@@ -0,0 +1,79 @@
+Feature: personal data redaction
+  Printed accounts and account builders must not reveal personal data unless explicitly asked to
+
+  Background:
+    Given my country code is "GB"$
+    And I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to "400515"$
+    And set bic to "NWBKGB22"$
+    And set iban to "GB82WEST12345698765432"$
+    And set account number to "31926819"$
+    And set first name to "Jane"$
+    And set account attributes:
+      | attribute              | value                 |
+      | name                   | Jane Doe              |
+      | user defined data      | crm=42;segment=retail |
+      | private identification | 1990-01-31/GB/AB123   |
+    And I have a valid account
+
+  Scenario Template: Personal data is masked with "<format>"
+    Then <value> printed with "<format>" contains "GB82 **** **** 5432"
+    And <value> printed with "<format>" contains "31****19"
+    And <value> printed with "<format>" contains "Ja****oe"
+    And <value> printed with "<format>" contains "segment"
+    And <value> printed with "<format>" contains "400515"
+    And <value> printed with "<format>" does not contain "GB82WEST12345698765432"
+    And <value> printed with "<format>" does not contain "31926819"
+    And <value> printed with "<format>" does not contain "Jane"
+    And <value> printed with "<format>" does not contain "retail"
+    And <value> printed with "<format>" does not contain "AB123"
+
+    Examples:
+      | value           | format |
+      | account         | %v     |
+      | account         | %+v    |
+      | account         | %#v    |
+      | account         | %s     |
+      | account         | %q     |
+      | account         | %d     |
+      | account builder | %v     |
+      | account builder | %+v    |
+      | account builder | %#v    |
+
+  Scenario Template: Personal data of accounts stored by value is masked with "<format>"
+    Then <value> printed with "<format>" contains "GB82 **** **** 5432"
+    And <value> printed with "<format>" contains "31****19"
+    And <value> printed with "<format>" does not contain "GB82WEST12345698765432"
+    And <value> printed with "<format>" does not contain "31926819"
+    And <value> printed with "<format>" does not contain "Jane"
+    And <value> printed with "<format>" does not contain "AB123"
+
+    Examples:
+      | value                 | format |
+      | account value         | %v     |
+      | account value         | %+v    |
+      | account value         | %#v    |
+      | account list          | %v     |
+      | account list          | %+v    |
+      | account builder value | %v     |
+
+  Scenario Template: Unredacted view prints personal data with "<format>"
+    Then <value> printed with "<format>" contains "GB82WEST12345698765432"
+    And <value> printed with "<format>" contains "31926819"
+    And <value> printed with "<format>" contains "Jane Doe"
+    And <value> printed with "<format>" contains "AB123"
+
+    Examples:
+      | value                      | format |
+      | unredacted account         | %v     |
+      | unredacted account         | %+v    |
+      | unredacted account builder | %v     |
+
+  Scenario: Log fields are masked
+    Then account log field "Iban" is "GB82 **** **** 5432"
+    And account log field "AccountNumber" is "31****19"
+    And account log field "FirstName" is "****"
+    And account log field "PrivateIdentification" is "[REDACTED]"
+    And account log field "BankID" is "400515"
@@ -0,0 +1,50 @@
+Feature: form3 api client retries
+  API Client must retry transient failures of idempotent requests
+
+  Background:
+    Given api stub server is started
+    And api client is created for api stub server
+
+  Scenario: Fetch is retried until API recovers
+    Given api stub server responds with status 503 to 2 request/s
+    When I run api client Fetch command for random ID
+    Then api client command succeeds
+    And api stub server received 3 request/s
+
+  Scenario: Retries are limited by retry policy
+    Given api stub server responds with status 502 to 10 request/s
+    When I run api client Fetch command for random ID
+    Then api client command fails
+    And api stub server received 4 request/s
+
+  Scenario: Errors which are not transient are not retried
+    Given api stub server responds with status 404 to 2 request/s
+    When I run api client Fetch command for random ID
+    Then api client command fails
+    And api stub server received 1 request/s
+
+  Scenario: Retry-After header is honoured
+    Given api stub server responds with status 429 and Retry-After 1 to 1 request/s
+    When I run api client Fetch command for random ID
+    Then api client command succeeds
+    And api stub server received 2 request/s
+    And api client command took at least 1 second/s
+
+  Scenario: Retries stop when context deadline runs out
+    Given api stub server responds with status 429 and Retry-After 2 to 1 request/s
+    When I run api client Fetch command for random ID with 1 second/s timeout
+    Then api client command fails
+    And api stub server received 1 request/s
+
+  Scenario: Create is not retried without idempotency key
+    Given api stub server responds with status 503 to 1 request/s
+    When I run api client Create command for random account
+    Then api client command fails
+    And api stub server received 1 request/s
+
+  Scenario: Create is retried with idempotency key
+    Given api stub server responds with status 503 to 1 request/s
+    When I run api client Create command for random account with idempotency key
+    Then api client command succeeds
+    And api stub server received 2 request/s
+    And api stub server received idempotency key on every request
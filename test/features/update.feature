@@ -0,0 +1,56 @@
+Feature: form3 api client update
+  API Client must update accounts with optimistic concurrency
+
+  Background:
+    Given api stub server is started
+    And api client is created for api stub server
+
+  Scenario: Update fetches, modifies and patches account
+    When I run api client Update command setting first name to "Alice"
+    Then api client command succeeds
+    And account first name is "Alice"$
+    And api stub server received 2 request/s
+    And api stub server received "PATCH" request for the account with version 1
+
+  Scenario: Update is repeated on version conflict
+    Given api stub server responds with status 409 and error message "invalid version" to 2 "PATCH" request/s
+    When I run api client Update command setting first name to "Alice"
+    Then api client command succeeds
+    And account first name is "Alice"$
+    And api stub server received 6 request/s
+    And api stub server received "PATCH" request for the account with version 5
+
+  Scenario: Update returns last conflict when attempts run out
+    Given api stub server responds with status 409 and error message "invalid version" to 10 "PATCH" request/s
+    When I run api client Update command setting first name to "Alice"
+    Then api client command fails with "version mismatch" error
+    And api stub server received 10 request/s
+
+  Scenario: Update is not repeated on other errors
+    Given api stub server responds with status 400 and error message "validation failure" to 1 "PATCH" request/s
+    When I run api client Update command setting first name to "Alice"
+    Then api client command fails with "validation" error
+    And api stub server received 2 request/s
+
+  Scenario: Update is not repeated on conflicts other than version mismatch
+    Given api stub server responds with status 409 and error message "duplicate constraint" to 1 "PATCH" request/s
+    When I run api client Update command setting first name to "Alice"
+    Then api client command fails with "conflict" error
+    And api stub server received 2 request/s
+
+  Scenario: Update is repeated on version conflict identified by error code
+    Given api stub server responds with status 409, error code "version_mismatch" and error message "record has been changed" to 1 "PATCH" request/s
+    When I run api client Update command setting first name to "Alice"
+    Then api client command succeeds
+    And api stub server received 4 request/s
+
+  Scenario: Update is not repeated on other conflict mentioning version
+    Given api stub server responds with status 409, error code "duplicate_id" and error message "version 0 already exists" to 1 "PATCH" request/s
+    When I run api client Update command setting first name to "Alice"
+    Then api client command fails with "conflict" error
+    And api stub server received 2 request/s
+
+  Scenario: Update stops when modification fails
+    When I run api client Update command with failing modification
+    Then api client command fails
+    And api stub server received 1 request/s
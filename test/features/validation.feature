@@ -0,0 +1,54 @@
+Feature: account validation errors
+  Builder must report every failing attribute with its setter, rule, masked value and country
+
+  Scenario: Every failing attribute is reported at once
+    Given my country code is "GB"$
+    When I create an account builder
+    And set account ID to "not-a-uuid"
+    And set random organization ID
+    And set bank ID to "1"$
+    And set bic to "DEUTDEFF"$
+    And set iban to "GB83WEST12345698765432"$
+    And set first name to "Wolfeschlegelsteinhausenbergerdorffwelchevoralternwarengewissenhaftschaferswessenschafewarenwohlgepflegeundsorgfaltigkeitbeschutzenvonangreifendurchihrraubgierigfeindewelchevoralternzwolftausendjahresvorandieerscheinenvanderersteerdemenschderraumschiffgebrauchlicht"$
+    Then I have an invalid account
+    And validation reports 5 error/s
+    And validation fails for "SetID" on "uuid" rule
+    And validation fails for "SetBankID" on "len=6" rule
+    And validation fails for "SetBic" on "bic_country" rule
+    And validation fails for "SetIban" on "iban_checksum" rule
+    And validation fails for "SetFirstName" on "max=40" rule
+
+  Scenario Template: Personal data is masked in validation errors
+    Given modulus tables are loaded from "testdata/vocalink_test_valacdos.txt" and "testdata/vocalink_test_scsubtab.txt"
+    And my country code is <country_code>$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    And set account number to <account_number>$
+    And set iban to <iban>$
+    Then I have an invalid account
+    And validation fails for <field> on <rule> rule
+    And validation error for <field> has value <value> and country <country_code>
+
+    Examples:
+      | country_code | bank_id     | account_number | iban                     | field              | rule            | value                    |
+      | "GB"         | "089999"    | "66374959"     | ""                       | "SetAccountNumber" | "modulus"       | "66****59"               |
+      | "DE"         | "12345678"  | ""             | "DE89370400440532013001" | "SetIban"          | "iban_checksum" | "DE******************01" |
+      | "US"         | "021000021" | ""             | "GB82WEST12345698765432" | "SetIban"          | "len=0"         | "GB******************32" |
+      | "BE"         | "123"       | ""             | "BE1"                    | "SetIban"          | "iban_format"   | "***"                    |
+
+  Scenario Template: Non personal data is reported as is
+    Given my country code is <country_code>$
+    When I create an account builder
+    And set random account ID
+    And set random organization ID
+    And set bank ID to <bank_id>$
+    Then I have an invalid account
+    And validation fails for "SetBankID" on <rule> rule
+    And validation error for "SetBankID" has value <bank_id> and country <country_code>
+
+    Examples:
+      | country_code | bank_id     | rule    |
+      | "GB"         | "12345"     | "len=6" |
+      | "US"         | "021000022" | "aba"   |
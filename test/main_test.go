@@ -0,0 +1,43 @@
+package test
+
+import (
+	"flag"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/DATA-DOG/godog"
+
+	"github.com/r0kas/form3-accountapi-client/accountserver"
+)
+
+// apiHostOverride replaces api host set in feature files.
+// Features run against local accounts API stand-in, unless ACCOUNTAPI_HOST points to a real one.
+var apiHostOverride = os.Getenv("ACCOUNTAPI_HOST")
+
+var godogFormat = flag.String("godog.format", "progress", "godog output format")
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	if apiHostOverride == "" {
+		server := httptest.NewServer(accountserver.New())
+		defer server.Close()
+		apiHostOverride = server.URL
+	}
+	return m.Run()
+}
+
+func TestFeatures(t *testing.T) {
+	status := godog.RunWithOptions("godog", FeatureContext, godog.Options{
+		Format: *godogFormat,
+		Paths:  []string{"features"},
+		Strict: true,
+	})
+	if status != 0 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DATA-DOG/godog"
+
+	account "github.com/r0kas/form3-accountapi-client"
+)
+
+// printedValue returns account, builder or their unredacted view, as named in features.
+func printedValue(value string) (interface{}, error) {
+	switch value {
+	case "account":
+		return theAccount, nil
+	case "account value":
+		return *theAccount, nil
+	case "account list":
+		return []account.Account{*theAccount}, nil
+	case "unredacted account":
+		return theAccount.Unredacted(), nil
+	case "account builder":
+		return accountBuilder, nil
+	case "account builder value":
+		return *accountBuilder, nil
+	case "unredacted account builder":
+		return accountBuilder.Unredacted(), nil
+	}
+	return nil, fmt.Errorf("unknown printed value %s", value)
+}
+
+func printedContains(value, format, expected string) error {
+	printable, err := printedValue(value)
+	if err != nil {
+		return err
+	}
+	if printed := fmt.Sprintf(format, printable); !strings.Contains(printed, expected) {
+		return fmt.Errorf("expected %s printed with %s to contain %q, got %s", value, format, expected, printed)
+	}
+	return nil
+}
+
+func printedDoesNotContain(value, format, unexpected string) error {
+	printable, err := printedValue(value)
+	if err != nil {
+		return err
+	}
+	if printed := fmt.Sprintf(format, printable); strings.Contains(printed, unexpected) {
+		return fmt.Errorf("expected %s printed with %s not to contain %q, got %s", value, format, unexpected, printed)
+	}
+	return nil
+}
+
+func logFieldIs(field, expected string) error {
+	actual, found := theAccount.LogFields()[field]
+	if !found {
+		return fmt.Errorf("log field %s is not set", field)
+	}
+	if fmt.Sprint(actual) != expected {
+		return fmt.Errorf("expected log field %s %q, got %q", field, expected, fmt.Sprint(actual))
+	}
+	return nil
+}
+
+func redactionContext(s *godog.Suite) {
+	s.Step(`^([a-z ]+) printed with "([^"]*)" contains "([^"]*)"$`, printedContains)
+	s.Step(`^([a-z ]+) printed with "([^"]*)" does not contain "([^"]*)"$`, printedDoesNotContain)
+	s.Step(`^account log field "([^"]*)" is "([^"]*)"$`, logFieldIs)
+}
@@ -0,0 +1,115 @@
+package account
+
+import (
+	"strings"
+
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/pl"
+	ut "github.com/go-playground/universal-translator"
+)
+
+// Locale selects language of validation messages.
+type Locale string
+
+// Supported validation message languages.
+const (
+	English Locale = "en"
+	German  Locale = "de"
+	French  Locale = "fr"
+	Spanish Locale = "es"
+	Polish  Locale = "pl"
+)
+
+///////
+// Messages are looked up by the most specific key first:
+// rule of the field in the country, rule of the field, then the rule alone,
+// e.g. 'len.SetBankID.GB', 'len.SetBankID', 'len'. Field names are looked up the same way.
+// Every message receives field name as {0} and rule parameter or country as {1}.
+///////
+var translations = ut.New(en.New(), en.New(), de.New(), fr.New(), es.New(), pl.New())
+
+func init() {
+	for locale, messages := range validationMessages {
+		translator, _ := translations.GetTranslator(string(locale))
+		for key, text := range messages {
+			if err := translator.Add(key, text, false); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+// rules which message refers to the country instead of the rule parameter.
+var countryParamRules = map[string]bool{
+	"len0":          true,
+	bicCountryRule:  true,
+	ibanCountryRule: true,
+	ibanLengthRule:  true,
+	ibanBbanRule:    true,
+}
+
+// translate returns human readable message of validation error in given language.
+// Unsupported locales fall back to English.
+func (e ValidationError) translate(locale Locale) string {
+	translator, found := translations.GetTranslator(string(locale))
+	if !found {
+		translator = translations.GetFallback()
+	}
+	country := e.Country.Code()
+
+	rule, param := splitRule(e.Rule)
+	if rule == "len" && param == "0" {
+		// zero length means attribute is not used in the country
+		rule = "len0"
+	}
+	if e.collection {
+		rule += "_items"
+	}
+	if countryParamRules[rule] {
+		param = country
+	} else if options := strings.Split(param, "|"); len(options) > 1 {
+		or, _ := translator.T("or")
+		param = strings.Join(options, " "+or+" ")
+	}
+
+	field := e.Field
+	if key, ok := lookup(translator, e.Field, country); ok {
+		field, _ = translator.T(key)
+	}
+	key, ok := lookup(translator, rule+"."+e.Field, country)
+	if !ok {
+		key = "invalid"
+	}
+	text, _ := translator.T(key, field, param)
+	return text
+}
+
+// finds most specific translation key out of 'key.country', 'key' and the rule part of the key.
+func lookup(translator ut.Translator, key, country string) (string, bool) {
+	candidates := []string{key + "." + country, key, strings.SplitN(key, ".", 2)[0]}
+	for _, candidate := range candidates {
+		// parameters are provided, as translations with placeholders require them
+		if _, err := translator.T(candidate, "", ""); err == nil {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// splits rule into its name and parameter, e.g. 'len=6' into 'len' and '6',
+// 'len=8|len=11' into 'len' and '8|11'.
+func splitRule(rule string) (string, string) {
+	options := strings.Split(rule, "|")
+	name := strings.SplitN(options[0], "=", 2)[0]
+	params := make([]string, 0, len(options))
+	for _, option := range options {
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) == 2 {
+			params = append(params, parts[1])
+		}
+	}
+	return name, strings.Join(params, "|")
+}
@@ -0,0 +1,304 @@
+package account
+
+///////
+// Messages name attributes the way account holders and bank staff know them,
+// e.g. 'sort code' instead of 'SetBankID', as they end up in front of end users.
+// Placeholders have to appear in order: {0} before {1}.
+///////
+var validationMessages = map[Locale]map[string]string{
+	English: {
+		"SetID":                         "account ID",
+		"SetOrganizationID":             "organisation ID",
+		"NewBuilder":                    "country",
+		"SetBankID":                     "bank ID",
+		"SetBankID.GB":                  "sort code",
+		"SetBankID.US":                  "routing number",
+		"SetBic":                        "BIC",
+		"SetIban":                       "IBAN",
+		"DeriveIban":                    "IBAN",
+		"SetAccountNumber":              "account number",
+		"SetBaseCurrency":               "base currency",
+		"SetCustomerID":                 "customer ID",
+		"SetTitle":                      "title",
+		"SetFirstName":                  "first name",
+		"SetBankAccountName":            "bank account name",
+		"SetAltBankAccountNames":        "alternative bank account name",
+		"SetAccountClassification":      "account classification",
+		"SetSecondaryIdentification":    "secondary identification",
+		"SetName":                       "account holder name",
+		"SetAlternativeNames":           "alternative account holder name",
+		"SetStatus":                     "status",
+		"SetStatusReason":               "status reason",
+		"SetUserDefinedData":            "user defined data",
+		"SetValidationType":             "validation type",
+		"SetReferenceMask":              "reference mask",
+		"SetAcceptanceQualifier":        "acceptance qualifier",
+		"SetProcessingService":          "processing service",
+		"SetPrivateIdentification":      "private identification",
+		"SetOrganisationIdentification": "organisation identification",
+		"or":                            "or",
+		"invalid":                       "{0} is invalid",
+		"required":                      "{0} is required",
+		"uuid":                          "{0} must be a UUID",
+		"eq":                            "{0} must be {1}",
+		"country_supported":             "{0} is not supported",
+		"len":                           "{0} must be {1} characters long",
+		"len.SetBankID":                 "{0} must be {1} digits",
+		"len0":                          "{0} is not used in {1}",
+		"max":                           "{0} must be at most {1} characters long",
+		"min":                           "{0} must be at least {1} characters long",
+		"digits":                        "{0} must contain digits only",
+		"alphanum":                      "{0} must contain letters and digits only",
+		"check_digits":                  "{0} has invalid national check digits",
+		"date":                          "{0} must be a date in YYYY-MM-DD format",
+		"identification":                "{0} cannot be set together with private identification",
+		"max_items":                     "{0} can be given at most {1} times",
+		"aba":                           "{0} has invalid check digit or Federal Reserve prefix",
+		"bic_format":                    "{0} must consist of 4 letter bank code, 2 letter country code, 2 character location code and optional 3 character branch code",
+		"bic_country":                   "{0} must belong to a bank in {1}",
+		"iban_format":                   "{0} must start with 2 letter country code and 2 check digits",
+		"iban_country":                  "{0} must be issued in {1}",
+		"iban_length":                   "{0} has invalid length for {1}",
+		"iban_bban":                     "{0} has invalid format for {1}",
+		"iban_checksum":                 "{0} has invalid check digits",
+		"iban_derivation":               "{0} cannot be derived from bank ID, account number and BIC",
+		"iban_derived":                  "{0} does not match bank ID, account number and BIC",
+		"modulus":                       "{0} does not match the sort code",
+	},
+	German: {
+		"SetID":                         "Konto-ID",
+		"SetOrganizationID":             "Organisations-ID",
+		"NewBuilder":                    "Land",
+		"SetBankID":                     "Bankleitzahl",
+		"SetBankID.GB":                  "Sort Code",
+		"SetBankID.US":                  "Routing Number",
+		"SetBic":                        "BIC",
+		"SetIban":                       "IBAN",
+		"DeriveIban":                    "IBAN",
+		"SetAccountNumber":              "Kontonummer",
+		"SetBaseCurrency":               "Basiswährung",
+		"SetCustomerID":                 "Kunden-ID",
+		"SetTitle":                      "Anrede",
+		"SetFirstName":                  "Vorname",
+		"SetBankAccountName":            "Kontoinhaber",
+		"SetAltBankAccountNames":        "alternativer Kontoinhaber",
+		"SetAccountClassification":      "Kontoart",
+		"SetSecondaryIdentification":    "sekundäre Identifikation",
+		"SetName":                       "Name des Kontoinhabers",
+		"SetAlternativeNames":           "alternativer Name des Kontoinhabers",
+		"SetStatus":                     "Status",
+		"SetStatusReason":               "Statusgrund",
+		"SetUserDefinedData":            "benutzerdefinierte Daten",
+		"SetValidationType":             "Validierungsart",
+		"SetReferenceMask":              "Referenzmaske",
+		"SetAcceptanceQualifier":        "Annahmequalifikator",
+		"SetProcessingService":          "Verarbeitungsdienst",
+		"SetPrivateIdentification":      "Identifikation der Privatperson",
+		"SetOrganisationIdentification": "Identifikation der Organisation",
+		"or":                            "oder",
+		"invalid":                       "{0} ist ungültig",
+		"required":                      "{0} ist erforderlich",
+		"uuid":                          "{0} muss eine UUID sein",
+		"eq":                            "{0} muss {1} sein",
+		"country_supported":             "{0} wird nicht unterstützt",
+		"len":                           "{0} muss {1} Zeichen lang sein",
+		"len.SetBankID":                 "{0} muss {1} Ziffern lang sein",
+		"len0":                          "{0} wird in {1} nicht verwendet",
+		"max":                           "{0} darf höchstens {1} Zeichen lang sein",
+		"min":                           "{0} muss mindestens {1} Zeichen lang sein",
+		"digits":                        "{0} darf nur Ziffern enthalten",
+		"alphanum":                      "{0} darf nur Buchstaben und Ziffern enthalten",
+		"check_digits":                  "{0} hat ungültige nationale Prüfziffern",
+		"date":                          "{0} muss ein Datum im Format JJJJ-MM-TT sein",
+		"identification":                "{0} kann nicht zusammen mit der Identifikation der Privatperson angegeben werden",
+		"max_items":                     "{0} darf höchstens {1} Mal angegeben werden",
+		"aba":                           "{0} hat eine ungültige Prüfziffer oder ein ungültiges Federal-Reserve-Präfix",
+		"bic_format":                    "{0} muss aus 4 Buchstaben Bankcode, 2 Buchstaben Ländercode, 2 Zeichen Ortscode und optional 3 Zeichen Filialcode bestehen",
+		"bic_country":                   "{0} muss zu einer Bank in {1} gehören",
+		"iban_format":                   "{0} muss mit 2 Buchstaben Ländercode und 2 Prüfziffern beginnen",
+		"iban_country":                  "{0} muss in {1} ausgestellt sein",
+		"iban_length":                   "{0} hat eine ungültige Länge für {1}",
+		"iban_bban":                     "{0} hat ein ungültiges Format für {1}",
+		"iban_checksum":                 "{0} hat ungültige Prüfziffern",
+		"iban_derivation":               "{0} kann nicht aus Bankleitzahl, Kontonummer und BIC abgeleitet werden",
+		"iban_derived":                  "{0} passt nicht zu Bankleitzahl, Kontonummer und BIC",
+		"modulus":                       "{0} passt nicht zum Sort Code",
+	},
+	French: {
+		"SetID":                         "l'identifiant du compte",
+		"SetOrganizationID":             "l'identifiant de l'organisation",
+		"NewBuilder":                    "le pays",
+		"SetBankID":                     "le code banque",
+		"SetBankID.GB":                  "le sort code",
+		"SetBankID.US":                  "le routing number",
+		"SetBic":                        "le BIC",
+		"SetIban":                       "l'IBAN",
+		"DeriveIban":                    "l'IBAN",
+		"SetAccountNumber":              "le numéro de compte",
+		"SetBaseCurrency":               "la devise de base",
+		"SetCustomerID":                 "l'identifiant client",
+		"SetTitle":                      "la civilité",
+		"SetFirstName":                  "le prénom",
+		"SetBankAccountName":            "le titulaire du compte",
+		"SetAltBankAccountNames":        "le titulaire alternatif du compte",
+		"SetAccountClassification":      "le type de compte",
+		"SetSecondaryIdentification":    "l'identification secondaire",
+		"SetName":                       "le nom du titulaire",
+		"SetAlternativeNames":           "le nom alternatif du titulaire",
+		"SetStatus":                     "le statut",
+		"SetStatusReason":               "le motif du statut",
+		"SetUserDefinedData":            "les données personnalisées",
+		"SetValidationType":             "le type de validation",
+		"SetReferenceMask":              "le masque de référence",
+		"SetAcceptanceQualifier":        "le qualificatif d'acceptation",
+		"SetProcessingService":          "le service de traitement",
+		"SetPrivateIdentification":      "l'identification de la personne",
+		"SetOrganisationIdentification": "l'identification de l'organisation",
+		"or":                            "ou",
+		"invalid":                       "{0} est invalide",
+		"required":                      "{0} est obligatoire",
+		"uuid":                          "{0} doit être un UUID",
+		"eq":                            "{0} doit être {1}",
+		"country_supported":             "{0} n'est pas pris en charge",
+		"len":                           "{0} doit comporter {1} caractères",
+		"len.SetBankID":                 "{0} doit comporter {1} chiffres",
+		"len0":                          "{0} n'est pas utilisé en {1}",
+		"max":                           "{0} doit comporter au plus {1} caractères",
+		"min":                           "{0} doit comporter au moins {1} caractères",
+		"digits":                        "{0} ne doit contenir que des chiffres",
+		"alphanum":                      "{0} ne doit contenir que des lettres et des chiffres",
+		"check_digits":                  "{0} a une clé nationale invalide",
+		"date":                          "{0} doit être une date au format AAAA-MM-JJ",
+		"identification":                "{0} ne peut pas être indiquée avec l'identification de la personne",
+		"max_items":                     "{0} peut être indiqué au plus {1} fois",
+		"aba":                           "{0} a une clé de contrôle ou un préfixe Federal Reserve invalide",
+		"bic_format":                    "{0} doit comporter un code banque de 4 lettres, un code pays de 2 lettres, un code emplacement de 2 caractères et un code agence facultatif de 3 caractères",
+		"bic_country":                   "{0} doit appartenir à une banque en {1}",
+		"iban_format":                   "{0} doit commencer par un code pays de 2 lettres et 2 chiffres de contrôle",
+		"iban_country":                  "{0} doit être émis en {1}",
+		"iban_length":                   "{0} a une longueur invalide pour {1}",
+		"iban_bban":                     "{0} a un format invalide pour {1}",
+		"iban_checksum":                 "{0} a des chiffres de contrôle invalides",
+		"iban_derivation":               "{0} ne peut pas être déduit du code banque, du numéro de compte et du BIC",
+		"iban_derived":                  "{0} ne correspond pas au code banque, au numéro de compte et au BIC",
+		"modulus":                       "{0} ne correspond pas au sort code",
+	},
+	Spanish: {
+		"SetID":                         "el identificador de la cuenta",
+		"SetOrganizationID":             "el identificador de la organización",
+		"NewBuilder":                    "el país",
+		"SetBankID":                     "el código de banco",
+		"SetBankID.GB":                  "el sort code",
+		"SetBankID.US":                  "el routing number",
+		"SetBic":                        "el BIC",
+		"SetIban":                       "el IBAN",
+		"DeriveIban":                    "el IBAN",
+		"SetAccountNumber":              "el número de cuenta",
+		"SetBaseCurrency":               "la moneda base",
+		"SetCustomerID":                 "el identificador del cliente",
+		"SetTitle":                      "el tratamiento",
+		"SetFirstName":                  "el nombre",
+		"SetBankAccountName":            "el titular de la cuenta",
+		"SetAltBankAccountNames":        "el titular alternativo de la cuenta",
+		"SetAccountClassification":      "el tipo de cuenta",
+		"SetSecondaryIdentification":    "la identificación secundaria",
+		"SetName":                       "el nombre del titular",
+		"SetAlternativeNames":           "el nombre alternativo del titular",
+		"SetStatus":                     "el estado",
+		"SetStatusReason":               "el motivo del estado",
+		"SetUserDefinedData":            "los datos definidos por el usuario",
+		"SetValidationType":             "el tipo de validación",
+		"SetReferenceMask":              "la máscara de referencia",
+		"SetAcceptanceQualifier":        "el calificador de aceptación",
+		"SetProcessingService":          "el servicio de procesamiento",
+		"SetPrivateIdentification":      "la identificación de la persona",
+		"SetOrganisationIdentification": "la identificación de la organización",
+		"or":                            "o",
+		"invalid":                       "{0} no es válido",
+		"required":                      "{0} es obligatorio",
+		"uuid":                          "{0} debe ser un UUID",
+		"eq":                            "{0} debe ser {1}",
+		"country_supported":             "{0} no está soportado",
+		"len":                           "{0} debe tener {1} caracteres",
+		"len.SetBankID":                 "{0} debe tener {1} dígitos",
+		"len0":                          "{0} no se usa en {1}",
+		"max":                           "{0} debe tener como máximo {1} caracteres",
+		"min":                           "{0} debe tener como mínimo {1} caracteres",
+		"digits":                        "{0} solo puede contener dígitos",
+		"alphanum":                      "{0} solo puede contener letras y dígitos",
+		"check_digits":                  "{0} tiene dígitos de control nacionales no válidos",
+		"date":                          "{0} debe ser una fecha en formato AAAA-MM-DD",
+		"identification":                "{0} no puede indicarse junto con la identificación de la persona",
+		"max_items":                     "{0} puede indicarse como máximo {1} veces",
+		"aba":                           "{0} tiene un dígito de control o un prefijo de la Reserva Federal no válido",
+		"bic_format":                    "{0} debe constar de un código de banco de 4 letras, un código de país de 2 letras, un código de localidad de 2 caracteres y un código de sucursal opcional de 3 caracteres",
+		"bic_country":                   "{0} debe pertenecer a un banco de {1}",
+		"iban_format":                   "{0} debe empezar por un código de país de 2 letras y 2 dígitos de control",
+		"iban_country":                  "{0} debe estar emitido en {1}",
+		"iban_length":                   "{0} tiene una longitud no válida para {1}",
+		"iban_bban":                     "{0} tiene un formato no válido para {1}",
+		"iban_checksum":                 "{0} tiene dígitos de control no válidos",
+		"iban_derivation":               "{0} no se puede obtener del código de banco, el número de cuenta y el BIC",
+		"iban_derived":                  "{0} no coincide con el código de banco, el número de cuenta y el BIC",
+		"modulus":                       "{0} no corresponde al sort code",
+	},
+	Polish: {
+		"SetID":                         "identyfikator rachunku",
+		"SetOrganizationID":             "identyfikator organizacji",
+		"NewBuilder":                    "kraj",
+		"SetBankID":                     "numer rozliczeniowy banku",
+		"SetBankID.GB":                  "sort code",
+		"SetBankID.US":                  "routing number",
+		"SetBic":                        "BIC",
+		"SetIban":                       "IBAN",
+		"DeriveIban":                    "IBAN",
+		"SetAccountNumber":              "numer rachunku",
+		"SetBaseCurrency":               "waluta bazowa",
+		"SetCustomerID":                 "identyfikator klienta",
+		"SetTitle":                      "forma grzecznościowa",
+		"SetFirstName":                  "imię",
+		"SetBankAccountName":            "nazwa posiadacza rachunku",
+		"SetAltBankAccountNames":        "alternatywna nazwa posiadacza rachunku",
+		"SetAccountClassification":      "rodzaj rachunku",
+		"SetSecondaryIdentification":    "identyfikacja dodatkowa",
+		"SetName":                       "nazwa posiadacza",
+		"SetAlternativeNames":           "alternatywna nazwa posiadacza",
+		"SetStatus":                     "status",
+		"SetStatusReason":               "przyczyna statusu",
+		"SetUserDefinedData":            "dane użytkownika",
+		"SetValidationType":             "rodzaj walidacji",
+		"SetReferenceMask":              "maska referencji",
+		"SetAcceptanceQualifier":        "kwalifikator akceptacji",
+		"SetProcessingService":          "usługa przetwarzania",
+		"SetPrivateIdentification":      "identyfikacja osoby",
+		"SetOrganisationIdentification": "identyfikacja organizacji",
+		"or":                            "lub",
+		"invalid":                       "{0}: nieprawidłowa wartość",
+		"required":                      "{0}: wartość jest wymagana",
+		"uuid":                          "{0}: wartość musi być identyfikatorem UUID",
+		"eq":                            "{0}: dozwolona wartość to {1}",
+		"country_supported":             "{0}: wartość nie jest obsługiwana",
+		"len":                           "{0}: wymagana długość to {1} znaków",
+		"len.SetBankID":                 "{0}: wymagana długość to {1} cyfr",
+		"len0":                          "{0}: nie jest używany w kraju {1}",
+		"max":                           "{0}: maksymalna długość to {1} znaków",
+		"min":                           "{0}: minimalna długość to {1} znaków",
+		"digits":                        "{0}: dozwolone są tylko cyfry",
+		"alphanum":                      "{0}: dozwolone są tylko litery i cyfry",
+		"check_digits":                  "{0}: nieprawidłowe krajowe cyfry kontrolne",
+		"date":                          "{0}: wymagana data w formacie RRRR-MM-DD",
+		"identification":                "{0}: nie można podać razem z identyfikacją osoby",
+		"max_items":                     "{0}: można podać maksymalnie {1} wartości",
+		"aba":                           "{0}: nieprawidłowa cyfra kontrolna lub prefiks Rezerwy Federalnej",
+		"bic_format":                    "{0}: wymagany 4-literowy kod banku, 2-literowy kod kraju, 2-znakowy kod lokalizacji i opcjonalny 3-znakowy kod oddziału",
+		"bic_country":                   "{0}: bank musi znajdować się w kraju {1}",
+		"iban_format":                   "{0}: musi zaczynać się 2-literowym kodem kraju i 2 cyframi kontrolnymi",
+		"iban_country":                  "{0}: musi być wydany w kraju {1}",
+		"iban_length":                   "{0}: nieprawidłowa długość dla kraju {1}",
+		"iban_bban":                     "{0}: nieprawidłowy format dla kraju {1}",
+		"iban_checksum":                 "{0}: nieprawidłowe cyfry kontrolne",
+		"iban_derivation":               "{0}: nie można wyznaczyć z numeru rozliczeniowego banku, numeru rachunku i BIC",
+		"iban_derived":                  "{0}: nie zgadza się z numerem rozliczeniowym banku, numerem rachunku i BIC",
+		"modulus":                       "{0}: nie pasuje do sort code",
+	},
+}
@@ -1,6 +1,9 @@
 package account
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type (
 	restTransport struct {
@@ -17,35 +20,53 @@ type (
 		Type           string            `json:"type"`
 		ID             string            `json:"id"`
 		OrganizationID string            `json:"organisation_id"`
-		Version        int               `json:"version,omitempty"`
+		Version        int               `json:"version"`
 		CreatedOn      time.Time         `json:"created_on,omitempty"`
 		ModifiedOn     time.Time         `json:"modified_on,omitempty"`
 		Attributes     accountAttributes `json:"attributes"`
+		Relationships  json.RawMessage   `json:"relationships,omitempty"`
+		Meta           json.RawMessage   `json:"meta,omitempty"`
 	}
 
 	accountAttributes struct {
-		Country                 string   `json:"country"`
-		BaseCurrency            string   `json:"base_currency,omitempty"`
-		BankID                  string   `json:"bank_id,omitempty"`
-		BankIDCode              string   `json:"bank_id_code,omitempty"`
-		AccountNumber           string   `json:"account_number,omitempty"`
-		Bic                     string   `json:"bic,omitempty"`
-		Iban                    string   `json:"iban,omitempty"`
-		CustomerID              string   `json:"customer_id,omitempty"`
-		Title                   string   `json:"title,omitempty"`
-		FirstName               string   `json:"first_name,omitempty"`
-		BankAccountName         string   `json:"bank_account_name,omitempty"`
-		AltBankAccountNames     []string `json:"alternative_bank_account_names,omitempty"`
-		AccountClassification   string   `json:"account_classification"`
-		JointAccount            bool     `json:"joint_account"`
-		AccountMatchingOptOut   bool     `json:"account_matching_opt_out"`
-		SecondaryIdentification string   `json:"secondary_identification,omitempty"`
+		Country                    string                      `json:"country"`
+		BaseCurrency               string                      `json:"base_currency,omitempty"`
+		BankID                     string                      `json:"bank_id,omitempty"`
+		BankIDCode                 string                      `json:"bank_id_code,omitempty"`
+		AccountNumber              string                      `json:"account_number,omitempty"`
+		Bic                        string                      `json:"bic,omitempty"`
+		Iban                       string                      `json:"iban,omitempty"`
+		CustomerID                 string                      `json:"customer_id,omitempty"`
+		Title                      string                      `json:"title,omitempty"`
+		FirstName                  string                      `json:"first_name,omitempty"`
+		BankAccountName            string                      `json:"bank_account_name,omitempty"`
+		AltBankAccountNames        []string                    `json:"alternative_bank_account_names,omitempty"`
+		AccountClassification      string                      `json:"account_classification"`
+		JointAccount               bool                        `json:"joint_account"`
+		AccountMatchingOptOut      bool                        `json:"account_matching_opt_out"`
+		SecondaryIdentification    string                      `json:"secondary_identification,omitempty"`
+		Name                       []string                    `json:"name,omitempty"`
+		AlternativeNames           []string                    `json:"alternative_names,omitempty"`
+		Status                     string                      `json:"status,omitempty"`
+		StatusReason               string                      `json:"status_reason,omitempty"`
+		Switched                   bool                        `json:"switched"`
+		UserDefinedData            []UserDefinedData           `json:"user_defined_data,omitempty"`
+		ValidationType             string                      `json:"validation_type,omitempty"`
+		ReferenceMask              string                      `json:"reference_mask,omitempty"`
+		AcceptanceQualifier        string                      `json:"acceptance_qualifier,omitempty"`
+		ProcessingService          string                      `json:"processing_service,omitempty"`
+		PrivateIdentification      *PrivateIdentification      `json:"private_identification,omitempty"`
+		OrganisationIdentification *OrganisationIdentification `json:"organisation_identification,omitempty"`
+
+		// attributes not modelled by the SDK, see Extra
+		extra map[string]json.RawMessage
 	}
 
 	links struct {
 		First string `json:"first,omitempty"`
 		Last  string `json:"last,omitempty"`
 		Next  string `json:"next,omitempty"`
+		Prev  string `json:"prev,omitempty"`
 		Self  string `json:"self,omitempty"`
 	}
 )
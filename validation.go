@@ -0,0 +1,187 @@
+package account
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type (
+	// ValidationError describes single account attribute which failed validation.
+	ValidationError struct {
+		// Field is the Builder method which sets the attribute, e.g. 'SetBankID'.
+		Field string
+		// Rule is the violated rule, e.g. 'len=6', 'aba', 'iban_checksum'.
+		Rule string
+		// Value is the offending value. Personal data, e.g. account number or name, is masked.
+		Value string
+		// Country which rule set was applied.
+		Country Country
+		// Message describes the problem in the Builder's locale, see Builder.SetLocale and ValidationErrors.Localize.
+		Message string
+
+		// rule applies to number of elements of a list attribute rather than to its elements
+		collection bool
+	}
+
+	// ValidationErrors lists every account attribute which failed validation.
+	// Returned by Builder.Validate, so UIs can highlight each form field:
+	//
+	//	var validationErrs account.ValidationErrors
+	//	if errors.As(err, &validationErrs) {
+	//		for _, e := range validationErrs {
+	//			// e.Field, e.Rule
+	//		}
+	//	}
+	ValidationErrors []ValidationError
+)
+
+// countrySupportedRule is reported when builder's country is not registered, see RegisterCountry.
+const countrySupportedRule = "country_supported"
+
+// identificationRule is reported when both private and organisation identification are set.
+const identificationRule = "identification"
+
+// digitsTag accepts digits only, unlike 'numeric' tag which accepts signs and decimals too.
+const digitsTag = "digits"
+
+// dateTag accepts dates in YYYY-MM-DD format, e.g. birth dates.
+const dateTag = "date"
+
+// newValidator creates validator with custom tags used by account attributes.
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	_ = validate.RegisterValidation(abaTag, func(fl validator.FieldLevel) bool {
+		return isABARoutingNumber(fl.Field().String())
+	})
+	_ = validate.RegisterValidation(digitsTag, func(fl validator.FieldLevel) bool {
+		return isDigits(fl.Field().String())
+	})
+	_ = validate.RegisterValidation(dateTag, func(fl validator.FieldLevel) bool {
+		_, err := time.Parse("2006-01-02", fl.Field().String())
+		return err == nil
+	})
+	return validate
+}
+
+// setters of attributes holding personal data, their values are masked in validation errors.
+var personalData = map[string]bool{
+	"SetAccountNumber":              true,
+	"SetIban":                       true,
+	"SetCustomerID":                 true,
+	"SetFirstName":                  true,
+	"SetBankAccountName":            true,
+	"SetAltBankAccountNames":        true,
+	"SetSecondaryIdentification":    true,
+	"SetName":                       true,
+	"SetAlternativeNames":           true,
+	"SetUserDefinedData":            true,
+	"SetPrivateIdentification":      true,
+	"SetOrganisationIdentification": true,
+}
+
+func (e ValidationError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: value '%s' violates '%s' rule of %s", e.Field, e.Value, e.Rule, e.Country.Code())
+}
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, validationErr := range e {
+		messages[i] = validationErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Localize returns copy of validation errors with messages in given language.
+// Unsupported locales fall back to English.
+func (e ValidationErrors) Localize(locale Locale) ValidationErrors {
+	localized := make(ValidationErrors, len(e))
+	for i, validationErr := range e {
+		validationErr.Message = validationErr.translate(locale)
+		localized[i] = validationErr
+	}
+	return localized
+}
+
+// HasField tells if attribute set by given Builder method failed validation.
+func (e ValidationErrors) HasField(field string) bool {
+	for _, validationErr := range e {
+		if validationErr.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// adds validation error, masking the value if it is personal data.
+func (e *ValidationErrors) add(country Country, field, rule, value string) {
+	e.addError(ValidationError{Field: field, Rule: rule, Value: value, Country: country})
+}
+
+func (e *ValidationErrors) addError(validationErr ValidationError) {
+	if personalData[validationErr.Field] {
+		validationErr.Value = maskPII(validationErr.Value)
+	}
+	*e = append(*e, validationErr)
+}
+
+// validates attributes struct and adds every failing field.
+// Fields are named after the Builder method set in their 'setter' tag.
+func (e *ValidationErrors) addStruct(country Country, validate *validator.Validate, attributes interface{}) {
+	err := validate.Struct(attributes)
+	fieldErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return
+	}
+	attributesType := reflect.TypeOf(attributes).Elem()
+	for _, fieldErr := range fieldErrs {
+		// namespace is 'attributes.Field', 'attributes.Field[i]' or 'attributes.Field.Nested' for nested structs
+		namespace := strings.SplitN(fieldErr.StructNamespace(), ".", 3)
+		name := strings.SplitN(namespace[1], "[", 2)[0]
+		field, _ := attributesType.FieldByName(name)
+		e.addError(ValidationError{
+			Field:      field.Tag.Get("setter"),
+			Rule:       ruleOf(fieldErr),
+			Value:      fmt.Sprint(fieldErr.Value()),
+			Country:    country,
+			collection: fieldErr.Kind() == reflect.Slice,
+		})
+	}
+}
+
+// validates single attribute against validator tag and adds it if it fails, empty tag accepts any value.
+func (e *ValidationErrors) addVar(country Country, validate *validator.Validate, field, value, tag string) {
+	if tag == "" {
+		return
+	}
+	fieldErrs, ok := validate.Var(value, tag).(validator.ValidationErrors)
+	if !ok || len(fieldErrs) == 0 {
+		return
+	}
+	e.add(country, field, ruleOf(fieldErrs[0]), value)
+}
+
+// returns violated rule with its parameter, e.g. 'len=6'. Or-rules, e.g. 'len=8|len=11', are reported as is.
+func ruleOf(fieldErr validator.FieldError) string {
+	rule := fieldErr.Tag()
+	if fieldErr.Param() != "" && !strings.Contains(rule, "=") {
+		rule += "=" + fieldErr.Param()
+	}
+	return rule
+}
+
+// maskPII keeps first and last 2 characters of the value, the rest is replaced with '*'.
+// Values up to 4 characters are masked completely.
+func maskPII(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:2]) + strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-2:])
+}